@@ -0,0 +1,64 @@
+package skiplist
+
+import "testing"
+
+func TestReadPathDoesNotAllocate(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+	for i := range 1024 {
+		m.Put(i, i)
+	}
+
+	it := m.Iterator()
+	cases := map[string]func(){
+		"Get":      func() { m.Get(512) },
+		"GetMiss":  func() { m.Get(4096) },
+		"Contains": func() { m.Contains(17) },
+		"SeekGE":   func() { it.SeekGE(300) },
+		"SeekLE":   func() { it.SeekLE(300) },
+		"Prev":     func() { it.SeekGE(300); it.Prev() },
+	}
+	for name, fn := range cases {
+		if allocs := testing.AllocsPerRun(100, fn); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %.1f", name, allocs)
+		}
+	}
+}
+
+func TestMutatorSearchDoesNotAllocate(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+	for i := range 1024 {
+		m.Put(i, i)
+	}
+
+	// Updating an existing key only boxes the new value.
+	if allocs := testing.AllocsPerRun(100, func() { m.Put(512, 1) }); allocs > 1 {
+		t.Errorf("Put update: expected at most 1 allocation, got %.1f", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { m.Delete(4096) }); allocs != 0 {
+		t.Errorf("Delete miss: expected no allocations, got %.1f", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { m.PutIfAbsent(512, 1) }); allocs > 1 {
+		t.Errorf("PutIfAbsent hit: expected at most 1 allocation, got %.1f", allocs)
+	}
+}
+
+func TestInlineValuesUpdateDoesNotAllocate(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := NewWithOptions[int, int](less, WithInlineValues(true))
+	for i := range 1024 {
+		m.Put(i, i)
+	}
+
+	cases := map[string]func(){
+		"Put":            func() { m.Put(512, 1) },
+		"CompareAndSwap": func() { CompareAndSwap(m, 512, 1, 1) },
+		"Compute":        func() { m.Compute(512, func(v int, _ bool) (int, Op) { return v + 1, OpUpdate }) },
+	}
+	for name, fn := range cases {
+		if allocs := testing.AllocsPerRun(100, fn); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %.1f", name, allocs)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package arenaskl
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// Arena is a fixed block of memory that holds the nodes, keys and values of
+// a Skiplist. It allocates by bumping an offset and never frees, so its
+// memory is released all at once when the arena is dropped. The block holds
+// no pointers, so the garbage collector does not scan it.
+type Arena struct {
+	// n is the offset of the next allocation. It may run past capacity when
+	// allocations fail; it is 64 bits wide so that it cannot wrap.
+	n   atomic.Uint64
+	buf []byte
+	// capacity is the number of usable bytes. buf is one node longer so that
+	// a node near the end can be viewed as a full node struct.
+	capacity uint32
+}
+
+// NewArena returns an arena that can allocate up to size bytes.
+func NewArena(size uint32) *Arena {
+	a := &Arena{
+		buf:      make([]byte, uint64(size)+uint64(maxNodeSize)),
+		capacity: size,
+	}
+	// Offset 0 stands for "no node", so nothing is allocated there.
+	a.n.Store(1)
+	return a
+}
+
+// Size returns the number of bytes allocated so far.
+func (a *Arena) Size() uint32 {
+	return uint32(min(a.n.Load(), uint64(a.capacity)))
+}
+
+// Capacity returns the number of bytes the arena can allocate.
+func (a *Arena) Capacity() uint32 {
+	return a.capacity
+}
+
+// alloc reserves size bytes aligned to align, which must be a power of two,
+// and returns their offset. It returns ErrArenaFull if they do not fit.
+func (a *Arena) alloc(size, align uint32) (uint32, error) {
+	padded := uint64(size) + uint64(align) - 1
+	end := a.n.Add(padded)
+	if end > uint64(a.capacity) {
+		return 0, ErrArenaFull
+	}
+	return (uint32(end-padded) + align - 1) &^ (align - 1), nil
+}
+
+// bytes returns the size bytes at offset. The slice's capacity ends with it,
+// so appending to it cannot overwrite the arena.
+func (a *Arena) bytes(offset, size uint32) []byte {
+	return a.buf[offset : offset+size : offset+size]
+}
+
+func (a *Arena) pointer(offset uint32) unsafe.Pointer {
+	return unsafe.Pointer(&a.buf[offset])
+}
@@ -0,0 +1,123 @@
+package arenaskl
+
+// Iterator provides a bidirectional view over a Skiplist, with the same
+// methods as the parent package's Iterator. Forward steps follow level-0
+// links; backward steps re-run the search to locate the predecessor, so each
+// Prev costs O(log n). Keys and values point into the arena and must not be
+// modified.
+type Iterator struct {
+	s *Skiplist
+	// nd is the offset of the current node, or 0 when the iterator is not
+	// valid.
+	nd uint32
+}
+
+// Iterator returns a new iterator positioned before the first element.
+func (s *Skiplist) Iterator() *Iterator {
+	return &Iterator{s: s}
+}
+
+// Valid reports whether the iterator currently points at an element.
+func (it *Iterator) Valid() bool {
+	return it != nil && it.nd != 0
+}
+
+// Key returns the key at the iterator's current position.
+// It should only be called when Valid reports true.
+func (it *Iterator) Key() []byte {
+	if !it.Valid() {
+		return nil
+	}
+	return it.s.key(it.s.node(it.nd))
+}
+
+// Value returns the value at the iterator's current position.
+// It should only be called when Valid reports true.
+func (it *Iterator) Value() []byte {
+	if !it.Valid() {
+		return nil
+	}
+	return it.s.value(it.s.node(it.nd))
+}
+
+// SeekGE positions the iterator at the first element whose key is
+// greater than or equal to the provided key. It returns true if such an
+// element exists.
+func (it *Iterator) SeekGE(key []byte) bool {
+	if it == nil || it.s == nil {
+		return false
+	}
+	_, it.nd, _ = it.s.search(key)
+	return it.nd != 0
+}
+
+// Next advances the iterator to the next element and reports whether it
+// successfully moved forward. If the iterator was not valid prior to the
+// call, it advances to the first element.
+func (it *Iterator) Next() bool {
+	if it == nil || it.s == nil {
+		return false
+	}
+	start := it.nd
+	if start == 0 {
+		start = it.s.head
+	}
+	it.nd = it.s.node(start).tower[0].Load()
+	return it.nd != 0
+}
+
+// SeekLT positions the iterator at the last element whose key is strictly
+// less than the provided key. It returns true if such an element exists.
+func (it *Iterator) SeekLT(key []byte) bool {
+	if it == nil || it.s == nil {
+		return false
+	}
+	prev, _, _ := it.s.search(key)
+	return it.setBefore(prev)
+}
+
+// SeekLE positions the iterator at the last element whose key is less than
+// or equal to the provided key. It returns true if such an element exists.
+func (it *Iterator) SeekLE(key []byte) bool {
+	if it == nil || it.s == nil {
+		return false
+	}
+	prev, next, found := it.s.search(key)
+	if found {
+		it.nd = next
+		return true
+	}
+	return it.setBefore(prev)
+}
+
+// Last positions the iterator at the final element. It returns true if the
+// skiplist is not empty.
+func (it *Iterator) Last() bool {
+	if it == nil || it.s == nil {
+		return false
+	}
+	return it.setBefore(it.s.findLast())
+}
+
+// Prev moves the iterator to the previous element and reports whether it
+// successfully moved backward. If the iterator was not valid prior to the
+// call, it moves to the last element.
+func (it *Iterator) Prev() bool {
+	if it == nil || it.s == nil {
+		return false
+	}
+	if it.nd == 0 {
+		return it.Last()
+	}
+	return it.SeekLT(it.Key())
+}
+
+// setBefore positions the iterator at prev, a predecessor returned by a
+// search, which is the head if no element precedes the search key.
+func (it *Iterator) setBefore(prev uint32) bool {
+	if prev == it.s.head {
+		prev = 0
+	}
+	it.nd = prev
+	return it.nd != 0
+}
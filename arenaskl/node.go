@@ -0,0 +1,79 @@
+package arenaskl
+
+import (
+	"math"
+	"math/bits"
+	"math/rand/v2"
+	"sync/atomic"
+	"unsafe"
+)
+
+// maxHeight is the tallest tower a node can have. With a promotion
+// probability of 1/4 it covers around 4^20 keys, far more than an arena of
+// 4GB can hold.
+const maxHeight = 20
+
+// node is the header of an entry in the arena. Its key and value follow the
+// allocated part of the tower.
+type node struct {
+	keyOffset uint32
+	keySize   uint32
+	valueSize uint32
+	// tower holds the arena offset of the next node on each level, or 0 at
+	// the end of the level. Only the first height entries are allocated.
+	tower [maxHeight]atomic.Uint32
+}
+
+const (
+	maxNodeSize = uint32(unsafe.Sizeof(node{}))
+	nodeAlign   = uint32(unsafe.Alignof(node{}))
+	linkSize    = uint32(unsafe.Sizeof(atomic.Uint32{}))
+)
+
+// newNode allocates a node of the given height holding copies of key and
+// value. It returns ErrArenaFull if the arena cannot hold them.
+func newNode(a *Arena, height uint32, key, value []byte) (uint32, *node, error) {
+	size, ok := entrySize(height, uint64(len(key)), uint64(len(value)))
+	if !ok || size > a.capacity {
+		return 0, nil, ErrArenaFull
+	}
+	nodeSize := towerSize(height)
+	keySize, valueSize := uint32(len(key)), uint32(len(value))
+	offset, err := a.alloc(size, nodeAlign)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// The arena is zeroed and never reused, so the tower starts out empty.
+	nd := (*node)(a.pointer(offset))
+	nd.keyOffset = offset + nodeSize
+	nd.keySize = keySize
+	nd.valueSize = valueSize
+	copy(a.bytes(nd.keyOffset, keySize), key)
+	copy(a.bytes(nd.keyOffset+keySize, valueSize), value)
+	return offset, nd, nil
+}
+
+// towerSize returns the size of a node header with height tower entries.
+func towerSize(height uint32) uint32 {
+	return maxNodeSize - (maxHeight-height)*linkSize
+}
+
+// entrySize returns the bytes a node of the given height needs for a key and
+// value of the given lengths. ok is false if that does not fit in a uint32
+// offset.
+func entrySize(height uint32, keyLen, valueLen uint64) (size uint32, ok bool) {
+	// Sum in 64 bits so that large keys or values cannot wrap.
+	total := uint64(towerSize(height)) + keyLen + valueLen
+	if total > math.MaxUint32 {
+		return 0, false
+	}
+	return uint32(total), true
+}
+
+// randomHeight draws a tower height, promoting each level with probability
+// 1/4.
+func randomHeight() uint32 {
+	h := uint32(bits.TrailingZeros64(rand.Uint64()))/2 + 1
+	return min(h, maxHeight)
+}
@@ -0,0 +1,202 @@
+// Package arenaskl implements a lock-free skiplist keyed by byte slices whose
+// nodes, keys and values live in a preallocated Arena and refer to each other
+// by offset rather than by pointer. The arena puts a hard cap on memory and
+// leaves the garbage collector nothing to scan, which suits memtables.
+//
+// Inserts use the same protocol as SkipListMap in the parent package: a
+// single CAS on level 0 makes a node present, after which its upper levels
+// are linked one CAS at a time. Entries cannot be updated or removed; the
+// whole skiplist is dropped together with its arena.
+package arenaskl
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+)
+
+var (
+	// ErrArenaFull is returned by Add when the arena has no room for the
+	// new entry.
+	ErrArenaFull = errors.New("arenaskl: arena is full")
+	// ErrRecordExists is returned by Add when the key is already present.
+	ErrRecordExists = errors.New("arenaskl: record with this key already exists")
+)
+
+// Skiplist is a concurrent ordered set of key/value pairs stored in an Arena.
+// Keys are ordered by bytes.Compare. Add, Get and iterators may be used from
+// any number of goroutines at once.
+type Skiplist struct {
+	arena *Arena
+	head  uint32
+	// height is the tallest tower linked so far; it never shrinks.
+	height atomic.Uint32
+	length atomic.Int64
+}
+
+// NewSkiplist returns an empty skiplist that allocates from arena. It panics
+// if the arena cannot hold the head node.
+func NewSkiplist(arena *Arena) *Skiplist {
+	head, _, err := newNode(arena, maxHeight, nil, nil)
+	if err != nil {
+		panic("arenaskl: arena is too small for the head node")
+	}
+	s := &Skiplist{arena: arena, head: head}
+	s.height.Store(1)
+	return s
+}
+
+// Arena returns the arena the skiplist allocates from.
+func (s *Skiplist) Arena() *Arena {
+	return s.arena
+}
+
+// LenInt64 returns the number of entries.
+func (s *Skiplist) LenInt64() int64 {
+	return s.length.Load()
+}
+
+// Add inserts key with value, copying both into the arena. It returns
+// ErrRecordExists if the key is present and ErrArenaFull if the entry does
+// not fit; in the latter case the skiplist is unchanged.
+func (s *Skiplist) Add(key, value []byte) error {
+	var prev, next [maxHeight]uint32
+	if s.findSplice(key, &prev, &next) {
+		return ErrRecordExists
+	}
+
+	height := randomHeight()
+	offset, nd, err := newNode(s.arena, height, key, value)
+	if err != nil {
+		return err
+	}
+	// Raise the height first so that any search that starts after the node
+	// appears on a level also walks that level.
+	s.raiseHeight(height)
+
+	// The node is present once it is linked on level 0.
+	for {
+		nd.tower[0].Store(next[0])
+		if s.node(prev[0]).tower[0].CompareAndSwap(next[0], offset) {
+			break
+		}
+		var c int
+		prev[0], next[0], c = s.findSpliceForLevel(key, 0, prev[0])
+		if c == 0 {
+			// A concurrent Add won; the node stays allocated but unreachable.
+			return ErrRecordExists
+		}
+	}
+	s.length.Add(1)
+
+	// Nodes are never removed, so a lost CAS on an upper level only means a
+	// node was inserted next to prev; walk on from prev to find the new spot.
+	for i := 1; i < int(height); i++ {
+		for {
+			nd.tower[i].Store(next[i])
+			if s.node(prev[i]).tower[i].CompareAndSwap(next[i], offset) {
+				break
+			}
+			prev[i], next[i], _ = s.findSpliceForLevel(key, i, prev[i])
+		}
+	}
+	return nil
+}
+
+// Get returns the value for key. The slice points into the arena and must
+// not be modified.
+func (s *Skiplist) Get(key []byte) ([]byte, bool) {
+	_, next, found := s.search(key)
+	if !found {
+		return nil, false
+	}
+	return s.value(s.node(next)), true
+}
+
+// Contains reports whether key is present.
+func (s *Skiplist) Contains(key []byte) bool {
+	_, _, found := s.search(key)
+	return found
+}
+
+// findSplice fills prev and next with the neighbours of key on every level
+// and reports whether next[0] holds key. Levels at or above the current
+// height get the head and the end of the level; an insert that links there
+// in the meantime makes the CAS in Add fail.
+func (s *Skiplist) findSplice(key []byte, prev, next *[maxHeight]uint32) (found bool) {
+	top := int(s.height.Load())
+	for i := top; i < maxHeight; i++ {
+		prev[i], next[i] = s.head, 0
+	}
+
+	x := s.head
+	c := 1
+	for i := top - 1; i >= 0; i-- {
+		x, next[i], c = s.findSpliceForLevel(key, i, x)
+		prev[i] = x
+	}
+	return c == 0
+}
+
+// search is the read-only counterpart of findSplice. It returns the level-0
+// predecessor and successor of key.
+func (s *Skiplist) search(key []byte) (prev, next uint32, found bool) {
+	prev = s.head
+	c := 1
+	for i := int(s.height.Load()) - 1; i >= 0; i-- {
+		prev, next, c = s.findSpliceForLevel(key, i, prev)
+	}
+	return prev, next, c == 0
+}
+
+// findSpliceForLevel walks level from start, which must be before key, to
+// the last node before key. It returns that node, its successor, and the
+// comparison of the successor's key with key; the end of the level compares
+// greater than every key.
+func (s *Skiplist) findSpliceForLevel(key []byte, level int, start uint32) (prev, next uint32, c int) {
+	prev = start
+	for {
+		next = s.node(prev).tower[level].Load()
+		if next == 0 {
+			return prev, 0, 1
+		}
+		if c = bytes.Compare(s.key(s.node(next)), key); c >= 0 {
+			return prev, next, c
+		}
+		prev = next
+	}
+}
+
+// findLast returns the last node on level 0, or the head if the skiplist is
+// empty.
+func (s *Skiplist) findLast() uint32 {
+	x := s.head
+	for i := int(s.height.Load()) - 1; i >= 0; i-- {
+		for next := s.node(x).tower[i].Load(); next != 0; next = s.node(x).tower[i].Load() {
+			x = next
+		}
+	}
+	return x
+}
+
+// raiseHeight lifts the current height to at least h.
+func (s *Skiplist) raiseHeight(h uint32) {
+	for {
+		cur := s.height.Load()
+		if cur >= h || s.height.CompareAndSwap(cur, h) {
+			return
+		}
+	}
+}
+
+func (s *Skiplist) node(offset uint32) *node {
+	return (*node)(s.arena.pointer(offset))
+}
+
+func (s *Skiplist) key(nd *node) []byte {
+	return s.arena.bytes(nd.keyOffset, nd.keySize)
+}
+
+func (s *Skiplist) value(nd *node) []byte {
+	return s.arena.bytes(nd.keyOffset+nd.keySize, nd.valueSize)
+}
@@ -0,0 +1,272 @@
+package arenaskl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func key(i int) []byte {
+	return fmt.Appendf(nil, "key-%05d", i)
+}
+
+func TestAddAndGet(t *testing.T) {
+	s := NewSkiplist(NewArena(1 << 16))
+	buf := []byte("apple")
+	if err := s.Add(buf, []byte("red")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Add copies the key, so the caller may reuse its buffer.
+	copy(buf, "grape")
+	if err := s.Add(buf, []byte("green")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for k, want := range map[string]string{"apple": "red", "grape": "green"} {
+		if v, ok := s.Get([]byte(k)); !ok || string(v) != want {
+			t.Fatalf("expected %s=%s, got %q (ok=%v)", k, want, v, ok)
+		}
+	}
+	if s.Contains([]byte("pear")) {
+		t.Fatal("expected pear to be absent")
+	}
+	if err := s.Add([]byte("apple"), []byte("yellow")); !errors.Is(err, ErrRecordExists) {
+		t.Fatalf("expected ErrRecordExists, got %v", err)
+	}
+	if n := s.LenInt64(); n != 2 {
+		t.Fatalf("expected length 2, got %d", n)
+	}
+}
+
+func TestAddEmptyKeyAndValue(t *testing.T) {
+	s := NewSkiplist(NewArena(1 << 12))
+	if err := s.Add(nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, ok := s.Get([]byte{}); !ok || len(v) != 0 {
+		t.Fatalf("expected the empty key with an empty value, got %q (ok=%v)", v, ok)
+	}
+}
+
+func TestAddReturnsErrArenaFull(t *testing.T) {
+	arena := NewArena(4096)
+	s := NewSkiplist(arena)
+
+	added := 0
+	var err error
+	for i := 0; ; i++ {
+		if err = s.Add(key(i), []byte("value")); err != nil {
+			break
+		}
+		added++
+	}
+	if !errors.Is(err, ErrArenaFull) {
+		t.Fatalf("expected ErrArenaFull, got %v", err)
+	}
+	if added == 0 {
+		t.Fatal("expected some entries to fit")
+	}
+	if arena.Size() > arena.Capacity() {
+		t.Fatalf("arena size %d exceeds capacity %d", arena.Size(), arena.Capacity())
+	}
+
+	// Everything added before the arena filled up is still readable.
+	if n := s.LenInt64(); n != int64(added) {
+		t.Fatalf("expected length %d, got %d", added, n)
+	}
+	for i := range added {
+		if _, ok := s.Get(key(i)); !ok {
+			t.Fatalf("expected %s to be present", key(i))
+		}
+	}
+	if err := s.Add(make([]byte, 8192), nil); !errors.Is(err, ErrArenaFull) {
+		t.Fatalf("expected ErrArenaFull for an oversized key, got %v", err)
+	}
+}
+
+func TestEntrySizeRejectsOverflow(t *testing.T) {
+	nodeSize := uint64(towerSize(maxHeight))
+	cases := []struct {
+		keyLen, valueLen uint64
+		ok               bool
+	}{
+		{keyLen: 8, valueLen: 8, ok: true},
+		{keyLen: math.MaxUint32 - nodeSize, ok: true},
+		{keyLen: math.MaxUint32 - nodeSize + 1},
+		{keyLen: math.MaxUint32 - 8, valueLen: 0},
+		{keyLen: math.MaxUint32, valueLen: math.MaxUint32},
+	}
+	for _, c := range cases {
+		size, ok := entrySize(maxHeight, c.keyLen, c.valueLen)
+		if ok != c.ok {
+			t.Errorf("entrySize(%d, %d): expected ok=%v, got %v", c.keyLen, c.valueLen, c.ok, ok)
+			continue
+		}
+		if ok && uint64(size) != nodeSize+c.keyLen+c.valueLen {
+			t.Errorf("entrySize(%d, %d): expected %d, got %d", c.keyLen, c.valueLen, nodeSize+c.keyLen+c.valueLen, size)
+		}
+	}
+}
+
+func TestNewSkiplistPanicsOnTinyArena(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected NewSkiplist to panic")
+		}
+	}()
+	NewSkiplist(NewArena(16))
+}
+
+func TestIterator(t *testing.T) {
+	s := NewSkiplist(NewArena(1 << 16))
+	for _, i := range []int{40, 10, 30, 20} {
+		if err := s.Add(key(i), key(i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var forward, backward []string
+	for it := s.Iterator(); it.Next(); {
+		forward = append(forward, string(it.Key()))
+	}
+	for it := s.Iterator(); it.Prev(); {
+		backward = append(backward, string(it.Key()))
+	}
+	want := []string{"key-00010", "key-00020", "key-00030", "key-00040"}
+	if !slices.Equal(forward, want) {
+		t.Fatalf("expected %v, got %v", want, forward)
+	}
+	slices.Reverse(want)
+	if !slices.Equal(backward, want) {
+		t.Fatalf("expected %v, got %v", want, backward)
+	}
+
+	it := s.Iterator()
+	cases := []struct {
+		name string
+		seek func([]byte) bool
+		key  int
+		want int // 0 when the seek should fail
+	}{
+		{"SeekGE hit", it.SeekGE, 20, 20},
+		{"SeekGE between", it.SeekGE, 25, 30},
+		{"SeekGE past end", it.SeekGE, 45, 0},
+		{"SeekLT hit", it.SeekLT, 20, 10},
+		{"SeekLT before start", it.SeekLT, 10, 0},
+		{"SeekLE hit", it.SeekLE, 20, 20},
+		{"SeekLE between", it.SeekLE, 25, 20},
+		{"SeekLE before start", it.SeekLE, 5, 0},
+	}
+	for _, tc := range cases {
+		ok := tc.seek(key(tc.key))
+		if ok != (tc.want != 0) || ok != it.Valid() {
+			t.Fatalf("%s: expected ok=%v, got %v (valid=%v)", tc.name, tc.want != 0, ok, it.Valid())
+		}
+		if ok && !bytes.Equal(it.Key(), key(tc.want)) {
+			t.Fatalf("%s: expected %s, got %s", tc.name, key(tc.want), it.Key())
+		}
+		if ok && !bytes.Equal(it.Value(), key(tc.want)) {
+			t.Fatalf("%s: expected value %s, got %s", tc.name, key(tc.want), it.Value())
+		}
+	}
+
+	if !it.Last() || string(it.Key()) != "key-00040" {
+		t.Fatalf("expected Last to land on key-00040, got %q", it.Key())
+	}
+	if it.Next() || it.Valid() || it.Key() != nil {
+		t.Fatal("expected Next past the end to invalidate the iterator")
+	}
+}
+
+func TestIteratorOnEmptySkiplist(t *testing.T) {
+	it := NewSkiplist(NewArena(1 << 12)).Iterator()
+	if it.Next() || it.Prev() || it.Last() || it.SeekGE(nil) || it.SeekLE(key(1)) {
+		t.Fatal("expected every move on an empty skiplist to fail")
+	}
+	var nilIt *Iterator
+	if nilIt.Valid() || nilIt.Next() {
+		t.Fatal("expected a nil iterator to be invalid")
+	}
+}
+
+func TestConcurrentAdds(t *testing.T) {
+	s := NewSkiplist(NewArena(1 << 22))
+	const goroutines, perGoroutine = 8, 1000
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var exists int
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perGoroutine {
+				// Every other key is shared with the neighbouring goroutine.
+				k := g*perGoroutine + i
+				if i%2 == 1 {
+					k = ((g+1)%goroutines)*perGoroutine + i - 1
+				}
+				err := s.Add(key(k), key(k))
+				if errors.Is(err, ErrRecordExists) {
+					mu.Lock()
+					exists++
+					mu.Unlock()
+				} else if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	const unique = goroutines * perGoroutine
+	if n := s.LenInt64(); n != unique/2 || exists != unique/2 {
+		t.Fatalf("expected %d entries and %d duplicates, got %d and %d", unique/2, unique/2, n, exists)
+	}
+	var prev []byte
+	count := 0
+	for it := s.Iterator(); it.Next(); count++ {
+		if prev != nil && bytes.Compare(prev, it.Key()) >= 0 {
+			t.Fatalf("keys out of order: %s then %s", prev, it.Key())
+		}
+		if !bytes.Equal(it.Key(), it.Value()) {
+			t.Fatalf("expected value %s, got %s", it.Key(), it.Value())
+		}
+		prev = it.Key()
+	}
+	if count != unique/2 {
+		t.Fatalf("expected to iterate %d entries, got %d", unique/2, count)
+	}
+}
+
+func TestOperationsDoNotAllocate(t *testing.T) {
+	s := NewSkiplist(NewArena(1 << 20))
+	keys := make([][]byte, 1000)
+	for i := range keys {
+		keys[i] = key(i)
+	}
+
+	i := 0
+	if allocs := testing.AllocsPerRun(100, func() {
+		s.Add(keys[i], keys[i])
+		i++
+	}); allocs != 0 {
+		t.Errorf("Add: expected no allocations, got %.1f", allocs)
+	}
+	it := s.Iterator()
+	cases := map[string]func(){
+		"Get":    func() { s.Get(keys[50]) },
+		"SeekGE": func() { it.SeekGE(keys[50]) },
+		"Prev":   func() { it.SeekGE(keys[50]); it.Prev() },
+	}
+	for name, fn := range cases {
+		if allocs := testing.AllocsPerRun(100, fn); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %.1f", name, allocs)
+		}
+	}
+}
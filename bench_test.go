@@ -127,3 +127,21 @@ func BenchmarkSkipListMapWorkloads(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkSortedAppend(b *testing.B) {
+	less := func(a, b int) bool { return a < b }
+
+	b.Run("Put", func(b *testing.B) {
+		m := New[int, int](less)
+		for i := 0; i < b.N; i++ {
+			m.Put(i, i)
+		}
+	})
+	b.Run("Cursor", func(b *testing.B) {
+		m := New[int, int](less)
+		cur := m.Cursor()
+		for i := 0; i < b.N; i++ {
+			cur.PutAfter(i, i)
+		}
+	})
+}
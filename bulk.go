@@ -0,0 +1,95 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+)
+
+var (
+	// ErrUnsorted is returned by FromSorted when a key is smaller than the key
+	// before it.
+	ErrUnsorted = errors.New("skiplist: keys are not in ascending order")
+	// ErrDuplicateKey is returned by FromSorted when a key repeats.
+	ErrDuplicateKey = errors.New("skiplist: duplicate key")
+)
+
+// FromSorted builds a map from seq, which must yield keys in strictly
+// ascending order according to less. It links the nodes bottom-up in a single
+// pass before the map is shared, without CAS or random draws. Tower heights
+// follow a fixed pattern: with promotion probability p, every
+// round(1/p)-th key reaches level 2, every round(1/p)²-th key reaches level 3,
+// and so on. Subsequent writes use the usual random heights.
+//
+// It returns ErrUnsorted or ErrDuplicateKey, wrapped with the offending key,
+// if seq is out of order.
+func FromSorted[K comparable, V any](less Less[K], seq iter.Seq2[K, V], opts ...func(*Config)) (*SkipListMap[K, V], error) {
+	return fill(NewWithOptions[K, V](less, opts...), seq)
+}
+
+// FromSortedCompare is FromSorted for a three-way comparison, as taken by
+// NewWithCompare; K need not be comparable.
+func FromSortedCompare[K, V any](compare Cmp[K], seq iter.Seq2[K, V], opts ...func(*Config)) (*SkipListMap[K, V], error) {
+	return fill(NewWithCompare[K, V](compare, opts...), seq)
+}
+
+// FromSortedBytes is FromSorted for a map built by NewBytes. Like Put on such
+// a map, it copies every key, so seq may reuse its buffers.
+func FromSortedBytes[V any](seq iter.Seq2[[]byte, V], opts ...func(*Config)) (*SkipListMap[[]byte, V], error) {
+	return fill(NewBytes[V](opts...), seq)
+}
+
+// fill links the entries of seq into the empty map m.
+func fill[K, V any](m *SkipListMap[K, V], seq iter.Seq2[K, V]) (*SkipListMap[K, V], error) {
+	stride := levelStride(m.rng.p)
+	last := make([]*node[K, V], m.maxLevel)
+	for i := range last {
+		last[i] = m.head
+	}
+	count := 0
+	tallest := 1
+	for k, v := range seq {
+		if prev := last[0]; prev != m.head {
+			switch c := m.compare(prev.key, k); {
+			case c == 0:
+				return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, k)
+			case c > 0:
+				return nil, fmt.Errorf("%w: %v after %v", ErrUnsorted, k, prev.key)
+			}
+		}
+
+		count++
+		height := 1
+		for i := count; height < m.maxLevel && i%stride == 0; i /= stride {
+			height++
+		}
+		tallest = max(tallest, height)
+
+		if m.cloneKey != nil {
+			k = m.cloneKey(k)
+		}
+		n := m.newNode(k, v, height)
+		toN := &link[K, V]{node: n}
+		for i := range height {
+			last[i].next[i].Store(toN)
+			last[i] = n
+		}
+	}
+
+	for i := range tallest {
+		last[i].next[i].Store(m.toTail)
+	}
+	m.raiseHeight(tallest)
+	m.metrics.AddLen(int64(count))
+	return m, nil
+}
+
+// levelStride returns how many keys of one level FromSorted passes for each
+// key it promotes to the next.
+func levelStride(p float64) int {
+	if p == 0 {
+		p = P
+	}
+	return int(min(max(2, math.Round(1/p)), math.MaxInt32))
+}
@@ -0,0 +1,132 @@
+package skiplist
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func sortedInts(keys ...int) func(func(int, int) bool) {
+	return func(yield func(int, int) bool) {
+		for _, k := range keys {
+			if !yield(k, k*10) {
+				return
+			}
+		}
+	}
+}
+
+func TestFromSortedBuildsPerfectTowers(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	keys := make([]int, 1024)
+	for i := range keys {
+		keys[i] = i * 2
+	}
+
+	m, err := FromSorted(less, sortedInts(keys...))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.LenInt64(); got != 1024 {
+		t.Fatalf("expected length 1024, got %d", got)
+	}
+	if got := slices.Collect(m.Keys()); !slices.Equal(got, keys) {
+		t.Fatalf("expected keys to round-trip")
+	}
+
+	// Level i holds every 2^i-th key.
+	for level := range 11 {
+		got := 0
+		for x := m.head.next[level].Load().node; x != m.tail; x = x.next[level].Load().node {
+			got++
+		}
+		if want := 1024 >> level; got != want {
+			t.Fatalf("level %d: expected %d nodes, got %d", level, want, got)
+		}
+	}
+	if got := m.topLevel(); got != 11 {
+		t.Fatalf("expected height 11, got %d", got)
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The map behaves like any other afterwards.
+	m.Put(3, 30)
+	m.Delete(4)
+	if v, ok := m.Get(3); !ok || v != 30 {
+		t.Fatalf("expected Get(3) = 30, got %d, %v", v, ok)
+	}
+	if m.Contains(4) {
+		t.Fatalf("expected key 4 to be deleted")
+	}
+	if k, _, ok := m.Last(); !ok || k != 2046 {
+		t.Fatalf("expected last key 2046, got %d", k)
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFromSortedUsesPromotionProbability(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	keys := make([]int, 64)
+	for i := range keys {
+		keys[i] = i
+	}
+
+	m, err := FromSorted(less, sortedInts(keys...), WithP(0.25), WithMaxLevel(3), WithInlineValues(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	heights := towerHeights(m)
+	want := []int{0, 48, 12, 4}
+	got := make([]int, 4)
+	for _, h := range heights {
+		got[h]++
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected height counts %v, got %v", want, got)
+	}
+	if v, ok := m.Get(63); !ok || v != 630 {
+		t.Fatalf("expected Get(63) = 630, got %d, %v", v, ok)
+	}
+}
+
+func TestFromSortedRejectsBadInput(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	if _, err := FromSorted(less, sortedInts(1, 3, 2)); !errors.Is(err, ErrUnsorted) {
+		t.Fatalf("expected ErrUnsorted, got %v", err)
+	}
+	if _, err := FromSorted(less, sortedInts(1, 2, 2)); !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("expected ErrDuplicateKey, got %v", err)
+	}
+
+	m, err := FromSorted(less, sortedInts())
+	if err != nil {
+		t.Fatalf("unexpected error for empty input: %v", err)
+	}
+	if _, _, ok := m.First(); ok || m.LenInt64() != 0 {
+		t.Fatalf("expected an empty map")
+	}
+}
+
+func TestFromSortedCompareUsesComparator(t *testing.T) {
+	reverse := func(a, b int) int { return b - a }
+
+	m, err := FromSortedCompare(reverse, sortedInts(5, 3, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := collectIntKeys(m); !slices.Equal(got, []int{5, 3, 1}) {
+		t.Fatalf("expected keys [5 3 1], got %v", got)
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FromSortedCompare(reverse, sortedInts(1, 3)); !errors.Is(err, ErrUnsorted) {
+		t.Fatalf("expected ErrUnsorted, got %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+package skiplist
+
+import "bytes"
+
+// NewBytes returns a new SkipListMap keyed by byte slices in bytes.Compare
+// order. A key is copied when its node is inserted, so callers may reuse the
+// buffer they pass to Put; keys returned by iterators belong to the map and
+// must not be modified. It panics under the same conditions as NewWithOptions.
+func NewBytes[V any](opts ...func(*Config)) *SkipListMap[[]byte, V] {
+	m := NewWithCompare[[]byte, V](bytes.Compare, opts...)
+	m.cloneKey = bytes.Clone
+	return m
+}
@@ -0,0 +1,106 @@
+package skiplist
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNewBytesCopiesKeysOnInsert(t *testing.T) {
+	m := NewBytes[int]()
+	buf := []byte("key-a")
+	m.Put(buf, 1)
+	// Reuse the buffer for the next key, as a storage layer would.
+	copy(buf, "key-b")
+	m.Put(buf, 2)
+
+	for k, want := range map[string]int{"key-a": 1, "key-b": 2} {
+		if v, ok := m.Get([]byte(k)); !ok || v != want {
+			t.Fatalf("expected %s=%d, got %d (ok=%v)", k, want, v, ok)
+		}
+	}
+	if n := m.LenInt64(); n != 2 {
+		t.Fatalf("expected length 2, got %d", n)
+	}
+}
+
+func TestNewBytesOrdersByBytesCompare(t *testing.T) {
+	m := NewBytes[int]()
+	keys := [][]byte{[]byte("b"), []byte("ab"), {}, []byte("a"), {0xff}, []byte("a\x00")}
+	for i, k := range keys {
+		m.Put(k, i)
+	}
+	// nil and the empty slice are the same key under bytes.Compare.
+	if _, replaced := m.Put(nil, 9); !replaced {
+		t.Fatal("expected nil to replace the empty key")
+	}
+
+	var got []string
+	for k := range m.Keys() {
+		got = append(got, fmt.Sprintf("%q", k))
+	}
+	want := []string{`""`, `"a"`, `"a\x00"`, `"ab"`, `"b"`, `"\xff"`}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected keys %v, got %v", want, got)
+	}
+
+	r := m.Range(Included([]byte("a")), Excluded([]byte("b")))
+	count := 0
+	for r.Next() {
+		if !bytes.HasPrefix(r.Key(), []byte("a")) {
+			t.Fatalf("unexpected key %q in range", r.Key())
+		}
+		count++
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 keys in [a, b), got %d", count)
+	}
+}
+
+func TestNewBytesLookupsDoNotAllocate(t *testing.T) {
+	m := NewBytes[int](WithInlineValues(true))
+	for i := range 1024 {
+		m.Put(fmt.Appendf(nil, "key-%04d", i), i)
+	}
+
+	key := []byte("key-0512")
+	cases := map[string]func(){
+		"Get":      func() { m.Get(key) },
+		"Contains": func() { m.Contains(key) },
+		// Updating an existing key keeps the stored copy.
+		"Put": func() { m.Put(key, 1) },
+	}
+	for name, fn := range cases {
+		if allocs := testing.AllocsPerRun(100, fn); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %.1f", name, allocs)
+		}
+	}
+}
+
+func TestFromSortedBytesCopiesKeys(t *testing.T) {
+	buf := []byte("key-0")
+	m, err := FromSortedBytes[int](func(yield func([]byte, int) bool) {
+		// Reuse one buffer for every key.
+		for i := range 3 {
+			buf[4] = byte('0' + i)
+			if !yield(buf, i) {
+				return
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range 3 {
+		k := fmt.Sprintf("key-%d", i)
+		if v, ok := m.Get([]byte(k)); !ok || v != i {
+			t.Fatalf("expected %s=%d, got %d (ok=%v)", k, i, v, ok)
+		}
+	}
+	if _, err := FromSortedBytes[int](func(yield func([]byte, int) bool) {
+		_ = yield([]byte("b"), 0) && yield([]byte("a"), 1)
+	}); !errors.Is(err, ErrUnsorted) {
+		t.Fatalf("expected ErrUnsorted, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"runtime/pprof"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -60,6 +61,10 @@ func TestConcurrentMixedOperationsStorm(t *testing.T) {
 
 	wg.Wait()
 
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
 	// Validate iterator consistency (no mutations during this phase)
 	observed := make(map[int]int)
 	it := m.Iterator()
@@ -246,6 +251,47 @@ func TestCascadeMarkerCleanup(t *testing.T) {
 	if it := m.SeekGE(0); it.Valid() {
 		t.Fatalf("expected no keys after full deletion, found key %d", it.Key())
 	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConcurrentDisjointDeletesAllSucceed(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	const totalKeys = 2000
+	for i := range totalKeys {
+		m.Put(i, i)
+	}
+
+	// Neighbouring keys belong to different workers, so unlinks often find
+	// their predecessor marked and must finish through a fresh search.
+	const workers = 8
+	var failed atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(offset int) {
+			defer wg.Done()
+			for k := offset; k < totalKeys; k += workers {
+				if v, ok := m.Delete(k); !ok || v != k {
+					failed.Add(1)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if n := failed.Load(); n != 0 {
+		t.Fatalf("expected every Delete of a present key to succeed, %d failed", n)
+	}
+	if got := m.LenInt64(); got != 0 {
+		t.Fatalf("expected map to be empty, got %d", got)
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
 }
 
 func TestPutGeneratorDoesNotBlock(t *testing.T) {
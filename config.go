@@ -0,0 +1,68 @@
+package skiplist
+
+// Config holds per-instance settings for a SkipListMap.
+type Config struct {
+	// maxLevel is the maximum tower height.
+	maxLevel int
+	// p is the probability for level promotion.
+	p float64
+	// seed makes level generation deterministic when seeded is set.
+	seed   int64
+	seeded bool
+	// metrics enables every counter reported by InsertCASStats and Stats.
+	metrics bool
+	// inline stores values in the node instead of a separate box.
+	inline bool
+	// tracer receives the map's internal events; nil disables tracing.
+	tracer Tracer
+}
+
+// NewConfig creates a Config with default values.
+func NewConfig() Config {
+	return Config{
+		maxLevel: MaxLevel,
+		p:        P,
+		metrics:  true,
+	}
+}
+
+// WithMaxLevel sets the maximum tower height. It must be in [1, MaxLevel].
+func WithMaxLevel(maxLevel int) func(*Config) {
+	return func(c *Config) { c.maxLevel = maxLevel }
+}
+
+// WithP sets the probability for level promotion. It must be in (0, 1).
+func WithP(p float64) func(*Config) {
+	return func(c *Config) { c.p = p }
+}
+
+// WithSeed makes level generation deterministic, which keeps tower shapes
+// reproducible in tests.
+func WithSeed(seed int64) func(*Config) {
+	return func(c *Config) {
+		c.seed = seed
+		c.seeded = true
+	}
+}
+
+// WithMetrics enables or disables the counters reported by InsertCASStats
+// and Stats. The length counter behind LenInt64 is always kept.
+func WithMetrics(enabled bool) func(*Config) {
+	return func(c *Config) { c.metrics = enabled }
+}
+
+// WithInlineValues stores values inside the nodes, guarded by a per-node
+// seqlock, instead of boxing each one. Updates then allocate nothing, at the
+// cost of writers to the same key waiting for each other briefly. Such maps
+// use a larger node layout, 24 bytes more per node; boxed maps do not carry
+// those words. It only applies to pointer-free value types of at most 16
+// bytes.
+func WithInlineValues(enabled bool) func(*Config) {
+	return func(c *Config) { c.inline = enabled }
+}
+
+// WithTracer reports the map's internal events to t. Without a tracer the map
+// skips the calls entirely.
+func WithTracer(t Tracer) func(*Config) {
+	return func(c *Config) { c.tracer = t }
+}
@@ -0,0 +1,190 @@
+package skiplist
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func towerHeights(m *SkipListMap[int, int]) []int {
+	var heights []int
+	for x := m.head.next[0].Load().node; x != m.tail; x = x.next[0].Load().node {
+		heights = append(heights, len(x.next))
+	}
+	return heights
+}
+
+func TestNewWithOptionsSeedIsDeterministic(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	a := NewWithOptions[int, int](less, WithSeed(42))
+	b := NewWithOptions[int, int](less, WithSeed(42))
+
+	for i := range 64 {
+		a.Put(i, i)
+		b.Put(i, i)
+	}
+
+	if ha, hb := towerHeights(a), towerHeights(b); !slices.Equal(ha, hb) {
+		t.Fatalf("expected identical tower heights for the same seed:\n%v\n%v", ha, hb)
+	}
+}
+
+func TestNewWithOptionsMaxLevel(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := NewWithOptions[int, int](less, WithMaxLevel(4), WithSeed(7))
+
+	if got := len(m.head.next); got != 4 {
+		t.Fatalf("expected head tower of height 4, got %d", got)
+	}
+
+	for i := range 4096 {
+		m.Put(i, i)
+	}
+	for _, h := range towerHeights(m) {
+		if h < 1 || h > 4 {
+			t.Fatalf("tower height %d outside [1, 4]", h)
+		}
+	}
+
+	for i := range 4096 {
+		if got, ok := m.Get(i); !ok || got != i {
+			t.Fatalf("expected Get(%d) to return (%d, true), got (%d, %v)", i, i, got, ok)
+		}
+	}
+	if it := m.SeekLT(100); !it.Valid() || it.Key() != 99 {
+		t.Fatalf("expected SeekLT(100) to land on 99")
+	}
+}
+
+func TestNewWithOptionsProbability(t *testing.T) {
+	const p = 0.25
+	rng := newRNGWithSeed(0x5eed)
+	rng.p = p
+
+	const samples = 200000
+	counts := make(map[int]int)
+	for range samples {
+		counts[rng.RandomLevel()]++
+	}
+
+	for i := 1; i < 4; i++ {
+		above := 0
+		for level, c := range counts {
+			if level > i {
+				above += c
+			}
+		}
+		atLeast := above + counts[i]
+		ratio := float64(above) / float64(atLeast)
+		tolerance := 5 * math.Sqrt(p*(1-p)/float64(atLeast))
+		if math.Abs(ratio-p) > tolerance {
+			t.Errorf("expected promotion ratio past level %d around %.2f ± %.4f, got %.4f", i, p, tolerance, ratio)
+		}
+	}
+}
+
+func TestNewWithOptionsDisablesMetrics(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := NewWithOptions[int, int](less, WithMetrics(false))
+
+	for i := range 100 {
+		m.Put(i, i)
+	}
+	m.Delete(0)
+
+	if retries, successes := m.InsertCASStats(); retries != 0 || successes != 0 {
+		t.Fatalf("expected zero CAS stats with metrics disabled, got (%d, %d)", retries, successes)
+	}
+	if st := m.Stats(); st != (Stats{Len: 99}) {
+		t.Fatalf("expected only the length in Stats with metrics disabled, got %+v", st)
+	}
+	if got := m.LenInt64(); got != 99 {
+		t.Fatalf("expected length 99 with metrics disabled, got %d", got)
+	}
+}
+
+func TestNewWithOptionsRejectsInvalidConfig(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for name, opt := range map[string]func(*Config){
+		"max level": WithMaxLevel(0),
+		"too tall":  WithMaxLevel(MaxLevel + 1),
+		"p zero":    WithP(0),
+		"p one":     WithP(1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected NewWithOptions to panic")
+				}
+			}()
+			NewWithOptions[int, int](less, opt)
+		})
+	}
+}
+
+func TestHeightTracksTallestTower(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := NewWithOptions[int, int](less, WithSeed(11))
+
+	if got := m.topLevel(); got != 1 {
+		t.Fatalf("expected empty map to start at height 1, got %d", got)
+	}
+
+	for i := range 2048 {
+		m.Put(i, i)
+	}
+	if got, want := m.topLevel(), slices.Max(towerHeights(m)); got != want {
+		t.Fatalf("expected height %d to match tallest tower, got %d", want, got)
+	}
+	for level := m.topLevel(); level < m.maxLevel; level++ {
+		if next := m.head.next[level].Load().node; next != m.tail {
+			t.Fatalf("expected level %d above the height to be empty", level)
+		}
+	}
+}
+
+func TestHeightConsistentUnderConcurrentInserts(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	const goroutines = 8
+	const perGoroutine = 1024
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func(base int) {
+			defer wg.Done()
+			for i := range perGoroutine {
+				m.Put(base+i*goroutines, i)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// Every tower must be linked on each of its levels, and every level must
+	// stay sorted, including levels raised while other inserts were running.
+	appearances := make(map[*node[int, int]]int)
+	for level := range m.maxLevel {
+		prev := -1
+		for x := m.head.next[level].Load().node; x != m.tail; x = x.next[level].Load().node {
+			if x.key <= prev {
+				t.Fatalf("level %d out of order: %d after %d", level, x.key, prev)
+			}
+			if level >= m.topLevel() {
+				t.Fatalf("node %d linked on level %d above height %d", x.key, level, m.topLevel())
+			}
+			prev = x.key
+			appearances[x]++
+		}
+	}
+	for n, count := range appearances {
+		if count != len(n.next) {
+			t.Fatalf("node %d of height %d linked on %d levels", n.key, len(n.next), count)
+		}
+	}
+	if len(appearances) != goroutines*perGoroutine {
+		t.Fatalf("expected %d nodes, found %d", goroutines*perGoroutine, len(appearances))
+	}
+}
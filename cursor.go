@@ -0,0 +1,28 @@
+package skiplist
+
+// Cursor speeds up runs of inserts in ascending key order, such as monotonic
+// IDs or timestamps. It remembers where its previous insert searched and
+// starts the next search there when the new key is larger, so appending
+// sorted keys costs amortized O(1) instead of a descent from the head.
+//
+// A Cursor must not be used from several goroutines at once, but the map it
+// writes to stays safe for concurrent use; nodes the cursor remembers that
+// were deleted meanwhile make it fall back to a full search.
+type Cursor[K, V any] struct {
+	m      *SkipListMap[K, V]
+	buf    searchBuf[K, V]
+	primed bool
+}
+
+// Cursor returns a new Cursor for m.
+func (m *SkipListMap[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{m: m}
+}
+
+// PutAfter behaves like Put. It is fastest when key is larger than the key of
+// the cursor's previous call; otherwise it searches from the head.
+func (c *Cursor[K, V]) PutAfter(key K, value V) (V, bool) {
+	old, replaced := c.m.mutator.insertFrom(key, value, true, &c.buf, c.primed)
+	c.primed = true
+	return old, replaced
+}
@@ -0,0 +1,143 @@
+package skiplist
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestCursorAppendsSortedKeys(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+	cur := m.Cursor()
+
+	const n = 5000
+	for i := range n {
+		if _, replaced := cur.PutAfter(i, i*10); replaced {
+			t.Fatalf("expected fresh insert for key %d", i)
+		}
+	}
+
+	keys := slices.Collect(m.Keys())
+	if len(keys) != n || !slices.IsSorted(keys) {
+		t.Fatalf("expected %d sorted keys, got %d", n, len(keys))
+	}
+	if got, ok := m.Get(1234); !ok || got != 12340 {
+		t.Fatalf("expected Get(1234) = 12340, got %d, %v", got, ok)
+	}
+	if gotLen := m.LenInt64(); gotLen != n {
+		t.Fatalf("expected length %d, got %d", n, gotLen)
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCursorAppendIsConstantTime(t *testing.T) {
+	var compares int
+	less := func(a, b int) bool {
+		compares++
+		return a < b
+	}
+	m := NewWithOptions[int, int](less, WithSeed(3))
+	for i := range 1 << 14 {
+		m.Put(i, i)
+	}
+
+	compares = 0
+	for i := 1 << 14; i < 1<<15; i++ {
+		m.Put(i, i)
+	}
+	perPut := float64(compares) / (1 << 14)
+
+	cur := m.Cursor()
+	compares = 0
+	for i := 1 << 15; i < 1<<15+1<<14; i++ {
+		cur.PutAfter(i, i)
+	}
+	perAppend := float64(compares) / (1 << 14)
+
+	t.Logf("comparisons per Put %.1f, per PutAfter %.1f", perPut, perAppend)
+	if perAppend > 4 {
+		t.Fatalf("expected PutAfter to need a constant number of comparisons, got %.1f", perAppend)
+	}
+}
+
+func TestCursorHandlesUnorderedKeysAndUpdates(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+	cur := m.Cursor()
+
+	keys := rand.Perm(2000)
+	for _, k := range keys {
+		cur.PutAfter(k, k)
+	}
+	for _, k := range keys[:100] {
+		if old, replaced := cur.PutAfter(k, -k); !replaced || old != k {
+			t.Fatalf("expected PutAfter(%d) to replace %d, got %d, %v", k, k, old, replaced)
+		}
+	}
+
+	got := slices.Collect(m.Keys())
+	if len(got) != len(keys) || !slices.IsSorted(got) {
+		t.Fatalf("expected %d sorted keys, got %d", len(keys), len(got))
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCursorFallsBackWhenFingerIsDeleted(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+	cur := m.Cursor()
+
+	for i := range 100 {
+		cur.PutAfter(i, i)
+	}
+	m.DeleteRange(0, 100)
+
+	for i := 100; i < 200; i++ {
+		cur.PutAfter(i, i)
+	}
+	if got := slices.Collect(m.Keys()); len(got) != 100 || got[0] != 100 || got[99] != 199 {
+		t.Fatalf("expected keys 100..199 after deleting the finger, got %v", got)
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCursorConcurrentAppends(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	const writers, perWriter = 4, 2000
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cur := m.Cursor()
+			for i := range perWriter {
+				k := i*writers + w
+				cur.PutAfter(k, k)
+				if i%5 == 0 {
+					m.Delete(k)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for k := range writers * perWriter {
+		want := (k/writers)%5 != 0
+		if m.Contains(k) != want {
+			t.Fatalf("key %d: expected present=%v", k, want)
+		}
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
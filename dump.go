@@ -0,0 +1,239 @@
+package skiplist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Formatter renders keys and values for WriteDOT and WriteASCII. A nil field
+// falls back to fmt.Sprint; a Value func that returns "" leaves values out.
+type Formatter[K, V any] struct {
+	Key   func(K) string
+	Value func(V) string
+}
+
+func (f Formatter[K, V]) label(key K, value V, live bool) string {
+	k := fmt.Sprint(key)
+	if f.Key != nil {
+		k = f.Key(key)
+	}
+	if !live {
+		return k + " (deleted)"
+	}
+	v := fmt.Sprint(value)
+	if f.Value != nil {
+		v = f.Value(value)
+	}
+	if v == "" {
+		return k
+	}
+	return k + "=" + v
+}
+
+// dumpNode is one node as WriteDOT and WriteASCII draw it.
+type dumpNode struct {
+	label   string
+	height  int
+	deleted bool
+	// marked reports whether the node's level-0 link is marked.
+	marked bool
+	// stray nodes are linked on an upper level but were not found on
+	// level 0.
+	stray bool
+	// short nodes are linked on a level their tower does not reach; the
+	// walk of that level stops at them.
+	short bool
+}
+
+// dump reads the map for WriteDOT and WriteASCII. It returns the nodes in
+// level-0 order followed by any strays, and for each level the indexes of
+// the nodes linked on it. Every level is read separately and without
+// helping, so concurrent writers can make the levels disagree.
+func (m *SkipListMap[K, V]) dump(f Formatter[K, V]) ([]dumpNode, [][]int) {
+	index := make(map[*node[K, V]]int)
+	var nodes []dumpNode
+	add := func(n *node[K, V], stray bool) int {
+		if i, ok := index[n]; ok {
+			return i
+		}
+		v, _, live := m.loadValue(n)
+		index[n] = len(nodes)
+		nodes = append(nodes, dumpNode{
+			label:   f.label(n.key, v, live),
+			height:  len(n.next),
+			deleted: !live,
+			marked:  n.next[0].Load().marked(),
+			stray:   stray,
+		})
+		return index[n]
+	}
+
+	levels := make([][]int, m.topLevel())
+	for level := range levels {
+		for n := m.head.next[level].Load().node; n != m.tail; n = n.next[level].Load().node {
+			i := add(n, level > 0)
+			levels[level] = append(levels[level], i)
+			if level >= len(n.next) {
+				nodes[i].short = true
+				break
+			}
+		}
+	}
+	return nodes, levels
+}
+
+// WriteDOT writes the map to w as a Graphviz digraph, one record per tower
+// with a field for each level. Logically deleted nodes are filled grey and
+// marked links, which freeze a node being deleted, are drawn as dashed red
+// edges. Nodes linked on an upper level but missing from level 0, or on a
+// level their tower does not reach, are drawn with a red outline. f formats
+// the labels. WriteDOT only reads links, so it
+// may run alongside writers, but it then shows no single moment.
+func (m *SkipListMap[K, V]) WriteDOT(w io.Writer, f Formatter[K, V]) error {
+	nodes, levels := m.dump(f)
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph skiplist {")
+	fmt.Fprintln(bw, "\trankdir=LR;")
+	fmt.Fprintln(bw, "\tnode [shape=record];")
+	fmt.Fprintf(bw, "\thead [label=\"{%shead}\"];\n", dotPorts(len(levels)))
+	for i, n := range nodes {
+		attrs := ""
+		switch {
+		case n.stray, n.short:
+			attrs = ", color=red"
+		case n.deleted:
+			attrs = ", style=filled, fillcolor=lightgrey"
+		}
+		fmt.Fprintf(bw, "\tn%d [label=\"{%s%s}\"%s];\n", i, dotPorts(n.height), dotEscape(n.label), attrs)
+	}
+	fmt.Fprintln(bw, "\ttail [label=\"tail\"];")
+
+	for level, chain := range levels {
+		from := "head"
+		marked := false
+		for _, i := range chain {
+			if level >= nodes[i].height {
+				// The tower has no field for this level; the walk ends here.
+				fmt.Fprintf(bw, "\t%s:l%d -> n%d [color=red];\n", from, level, i)
+				from = ""
+				break
+			}
+			fmt.Fprintf(bw, "\t%s:l%d -> n%d:l%d%s;\n", from, level, i, level, dotMarked(marked))
+			from = fmt.Sprintf("n%d", i)
+			marked = level == 0 && nodes[i].marked
+		}
+		if from != "" {
+			fmt.Fprintf(bw, "\t%s:l%d -> tail%s;\n", from, level, dotMarked(marked))
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// WriteASCII writes the map to w as text, one line per level from the top
+// down, with each node in the column of its level-0 position:
+//
+//	L1 head ---------> 2=20 ---------> nil
+//	L0 head -> 1=10 -> 2=20 -> 3=30 -> nil
+//
+// Logically deleted nodes read "key (deleted)" and a marked link is drawn
+// "=>" instead of "->". Nodes linked on an upper level but missing from
+// level 0 are listed after the levels, and so are nodes linked on a level
+// their tower does not reach, where that level's line ends in "?". f formats
+// the labels. WriteASCII only
+// reads links, so it may run alongside writers, but it then shows no single
+// moment.
+func (m *SkipListMap[K, V]) WriteASCII(w io.Writer, f Formatter[K, V]) error {
+	nodes, levels := m.dump(f)
+	bw := bufio.NewWriter(w)
+	writeASCII(bw, nodes, levels)
+	return bw.Flush()
+}
+
+// writeASCII draws the levels returned by dump. Strays come after the level-0
+// nodes, so the level-0 nodes' indexes are their columns.
+func writeASCII(w io.Writer, nodes []dumpNode, levels [][]int) {
+	columns := 0
+	if len(levels) > 0 {
+		columns = len(levels[0])
+	}
+	var strays, shorts []string
+	prefix := len(fmt.Sprintf("L%d", len(levels)-1))
+	for level := len(levels) - 1; level >= 0; level-- {
+		var b strings.Builder
+		fmt.Fprintf(&b, "%-*s head ", prefix, fmt.Sprintf("L%d", level))
+		col, arrow := 0, "-> "
+		cut := false
+		for _, i := range levels[level] {
+			if level >= nodes[i].height {
+				shorts = append(shorts, fmt.Sprintf("L%d: %s", level, nodes[i].label))
+				cut = true
+			}
+			if nodes[i].stray {
+				strays = append(strays, fmt.Sprintf("L%d: %s", level, nodes[i].label))
+				continue
+			}
+			for ; col < i; col++ {
+				b.WriteString(strings.Repeat("-", utf8.RuneCountInString(nodes[col].label)+4))
+			}
+			b.WriteString(arrow)
+			b.WriteString(nodes[i].label)
+			b.WriteString(" ")
+			col++
+			arrow = "-> "
+			if level == 0 && nodes[i].marked {
+				arrow = "=> "
+			}
+		}
+		if cut {
+			// The walk stopped at a tower too short for this level.
+			b.WriteString("-> ?")
+			fmt.Fprintln(w, b.String())
+			continue
+		}
+		for ; col < columns; col++ {
+			b.WriteString(strings.Repeat("-", utf8.RuneCountInString(nodes[col].label)+4))
+		}
+		b.WriteString(arrow)
+		b.WriteString("nil")
+		fmt.Fprintln(w, b.String())
+	}
+	for _, s := range strays {
+		fmt.Fprintf(w, "not on level 0: %s\n", s)
+	}
+	for _, s := range shorts {
+		fmt.Fprintf(w, "tower too short: %s\n", s)
+	}
+}
+
+// dotPorts returns the record fields for a tower of the given height, top
+// level first, each followed by a separator.
+func dotPorts(height int) string {
+	var b strings.Builder
+	for level := height - 1; level >= 0; level-- {
+		fmt.Fprintf(&b, "<l%d> |", level)
+	}
+	return b.String()
+}
+
+func dotMarked(marked bool) string {
+	if marked {
+		return " [style=dashed, color=red]"
+	}
+	return ""
+}
+
+// dotEscape escapes the characters that are special in record labels.
+func dotEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`{}|<>"\ `, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
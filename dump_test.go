@@ -0,0 +1,141 @@
+package skiplist
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteASCII(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m, err := FromSorted(less, sortedInts(1, 2, 3, 4, 5))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Leave 3 deleted and marked but still linked.
+	_, n, _ := m.search(3)
+	m.mutator.logicalDelete(n)
+	m.mark(n)
+
+	var b strings.Builder
+	if err := m.WriteASCII(&b, Formatter[int, int]{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "" +
+		"L2 head --------------------------------> 4=40 ---------> nil\n" +
+		"L1 head ---------> 2=20 ----------------> 4=40 ---------> nil\n" +
+		"L0 head -> 1=10 -> 2=20 -> 3 (deleted) => 4=40 -> 5=50 -> nil\n"
+	if b.String() != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, b.String())
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m, err := FromSorted(less, sortedInts(1, 2, 3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, n, _ := m.search(1)
+	m.mutator.logicalDelete(n)
+	m.mark(n)
+
+	var b strings.Builder
+	f := Formatter[int, int]{
+		Key:   func(k int) string { return "<" + strconv.Itoa(k) + ">" },
+		Value: func(int) string { return "" },
+	}
+	if err := m.WriteDOT(&b, f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := b.String()
+	for _, line := range []string{
+		"digraph skiplist {",
+		`head [label="{<l1> |<l0> |head}"];`,
+		`n0 [label="{<l0> |\<1\>\ (deleted)}", style=filled, fillcolor=lightgrey];`,
+		`n1 [label="{<l1> |<l0> |\<2\>}"];`,
+		"head:l0 -> n0:l0;",
+		"n0:l0 -> n1:l0 [style=dashed, color=red];",
+		"n2:l0 -> tail;",
+		"head:l1 -> n1:l1;",
+		"n1:l1 -> tail;",
+	} {
+		if !strings.Contains(out, "\t"+line+"\n") && !strings.HasPrefix(out, line+"\n") {
+			t.Errorf("expected line %q in\n%s", line, out)
+		}
+	}
+}
+
+func TestDumpMarksShortTowers(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m, err := FromSorted(less, sortedInts(1, 2, 3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Link 1, whose tower has height 1, on level 1.
+	_, n, _ := m.search(1)
+	m.head.next[1].Store(linkTo(n))
+
+	var b strings.Builder
+	if err := m.WriteASCII(&b, Formatter[int, int]{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "" +
+		"L1 head -> 1=10 -> ?\n" +
+		"L0 head -> 1=10 -> 2=20 -> 3=30 -> nil\n" +
+		"tower too short: L1: 1=10\n"
+	if b.String() != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, b.String())
+	}
+
+	b.Reset()
+	if err := m.WriteDOT(&b, Formatter[int, int]{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := b.String()
+	for _, line := range []string{
+		`n0 [label="{<l0> |1=10}", color=red];`,
+		"head:l1 -> n0 [color=red];",
+	} {
+		if !strings.Contains(out, "\t"+line+"\n") {
+			t.Errorf("expected line %q in\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, "n0:l1") {
+		t.Errorf("expected no edge from the missing level-1 field in\n%s", out)
+	}
+}
+
+func TestWriteASCIIEmptyMap(t *testing.T) {
+	var b strings.Builder
+	if err := NewOrdered[int, int]().WriteASCII(&b, Formatter[int, int]{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "L0 head -> nil\n"; b.String() != want {
+		t.Fatalf("expected %q, got %q", want, b.String())
+	}
+}
+
+func TestWriteDOTConcurrentWithWriters(t *testing.T) {
+	m := NewOrdered[int, int]()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 2000 {
+			m.Put(i, i)
+			if i%2 == 0 {
+				m.Delete(i / 2)
+			}
+		}
+	}()
+	for range 20 {
+		if err := m.WriteDOT(io.Discard, Formatter[int, int]{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := m.WriteASCII(io.Discard, Formatter[int, int]{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	<-done
+}
@@ -56,3 +56,42 @@ func ExampleSkipListMap_SeekGE() {
 	fmt.Println()
 	// Output: 3:three 5:five
 }
+
+func ExampleSkipListMap_SeekLE() {
+	m := New[int, string](func(a, b int) bool { return a < b })
+	m.Put(1, "one")
+	m.Put(3, "three")
+	m.Put(5, "five")
+	it := m.SeekLE(4)
+	for it.Valid() {
+		fmt.Printf("%d:%s ", it.Key(), it.Value())
+		it.Prev()
+	}
+	fmt.Println()
+	// Output: 3:three 1:one
+}
+
+func ExampleSkipListMap_Range() {
+	m := New[int, string](func(a, b int) bool { return a < b })
+	for i, s := range []string{"zero", "one", "two", "three", "four"} {
+		m.Put(i, s)
+	}
+	it := m.Range(Excluded(0), Included(3))
+	for it.Next() {
+		fmt.Printf("%d:%s ", it.Key(), it.Value())
+	}
+	fmt.Println()
+	// Output: 1:one 2:two 3:three
+}
+
+func ExampleSkipListMap_All() {
+	m := New[int, string](func(a, b int) bool { return a < b })
+	m.Put(2, "two")
+	m.Put(1, "one")
+	m.Put(3, "three")
+	for k, v := range m.All() {
+		fmt.Printf("%d:%s ", k, v)
+	}
+	fmt.Println()
+	// Output: 1:one 2:two 3:three
+}
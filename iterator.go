@@ -1,7 +1,9 @@
 package skiplist
 
-// Iterator provides a forward-only view over the skip list.
-type Iterator[K comparable, V any] struct {
+// Iterator provides a bidirectional view over the skip list. Forward steps
+// follow level-0 links; backward steps re-run the search to locate the
+// predecessor, so each Prev costs O(log n).
+type Iterator[K, V any] struct {
 	m       *SkipListMap[K, V]
 	current *node[K, V]
 	key     K
@@ -52,20 +54,15 @@ func (it *Iterator[K, V]) SeekGE(key K) bool {
 
 	it.invalidate()
 
-	_, succs, _ := it.m.find(key)
-	current := succs[0]
+	_, current, _ := it.m.search(key)
 
 	for {
 		if current == nil || current == it.m.tail {
 			return false
 		}
 
-		valPtr := current.val.Load()
-		if valPtr != nil {
-			it.current = current
-			it.key = current.key
-			it.value = *valPtr
-			it.valid = true
+		if v, _, ok := it.m.loadValue(current); ok {
+			it.set(current, v)
 			return true
 		}
 
@@ -97,22 +94,115 @@ func (it *Iterator[K, V]) Next() bool {
 			return false
 		}
 
-		valPtr := next.val.Load()
-		if valPtr == nil {
+		v, _, ok := it.m.loadValue(next)
+		if !ok {
 			// The node was logically deleted before we could observe its value.
 			// Continue the traversal from this node to locate the next live one.
 			start = next
 			continue
 		}
 
-		it.current = next
-		it.key = next.key
-		it.value = *valPtr
-		it.valid = true
+		it.set(next, v)
 		return true
 	}
 }
 
+// SeekLT positions the iterator at the last element whose key is strictly
+// less than the provided key. It returns true if such an element exists.
+func (it *Iterator[K, V]) SeekLT(key K) bool {
+	if it == nil || it.m == nil {
+		return false
+	}
+
+	it.invalidate()
+	return it.seekBefore(key)
+}
+
+// SeekLE positions the iterator at the last element whose key is less than
+// or equal to the provided key. It returns true if such an element exists.
+func (it *Iterator[K, V]) SeekLE(key K) bool {
+	if it == nil || it.m == nil {
+		return false
+	}
+
+	it.invalidate()
+
+	_, current, found := it.m.search(key)
+	if found {
+		if v, _, ok := it.m.loadValue(current); ok {
+			it.set(current, v)
+			return true
+		}
+	}
+	return it.seekBefore(key)
+}
+
+// Last positions the iterator at the final element. It returns true if the
+// skip list is not empty.
+func (it *Iterator[K, V]) Last() bool {
+	if it == nil || it.m == nil {
+		return false
+	}
+
+	it.invalidate()
+
+	last := it.m.findLast()
+	if last == nil || last == it.m.head {
+		return false
+	}
+	if v, _, ok := it.m.loadValue(last); ok {
+		it.set(last, v)
+		return true
+	}
+	// The last node was deleted after the search passed it; fall back to its
+	// live predecessor.
+	return it.seekBefore(last.key)
+}
+
+// Prev moves the iterator to the previous element and reports whether it
+// successfully moved backward. If the iterator was not valid prior to the
+// call, it moves to the last element.
+func (it *Iterator[K, V]) Prev() bool {
+	if it == nil || it.m == nil {
+		return false
+	}
+
+	if !it.valid {
+		return it.Last()
+	}
+
+	key := it.key
+	it.invalidate()
+	return it.seekBefore(key)
+}
+
+// seekBefore positions the iterator at the last live element whose key is
+// strictly less than key, using the level-0 predecessor computed by search.
+func (it *Iterator[K, V]) seekBefore(key K) bool {
+	for {
+		pred, _, _ := it.m.search(key)
+		if pred == nil || pred == it.m.head {
+			return false
+		}
+
+		if v, _, ok := it.m.loadValue(pred); ok {
+			it.set(pred, v)
+			return true
+		}
+
+		// The predecessor was deleted after the search stepped over it.
+		// Search again strictly before it; keys only decrease, so this ends.
+		key = pred.key
+	}
+}
+
+func (it *Iterator[K, V]) set(n *node[K, V], value V) {
+	it.current = n
+	it.key = n.key
+	it.value = value
+	it.valid = true
+}
+
 func (it *Iterator[K, V]) invalidate() {
 	if it == nil {
 		return
@@ -1,6 +1,7 @@
 package skiplist
 
 import (
+	"slices"
 	"sync"
 	"testing"
 )
@@ -138,22 +139,21 @@ func TestIteratorSeekGESkipsLogicallyDeletedNodes(t *testing.T) {
 	}
 }
 
-func TestIteratorSkipsMarkersDuringConcurrentDeletion(t *testing.T) {
+func TestIteratorSkipsMarkedNodesDuringConcurrentDeletion(t *testing.T) {
 	less := func(a, b int) bool { return a < b }
-	m := New[int, int](less)
-
-	m.Put(1, 1)
-	m.Put(2, 2)
-
-	markerReady := make(chan struct{})
+	markReady := make(chan struct{})
 	resume := make(chan struct{})
 	var once sync.Once
+	tr := &funcTracer{mark: func(any) {
+		once.Do(func() {
+			close(markReady)
+			<-resume
+		})
+	}}
+	m := NewWithOptions[int, int](less, WithTracer(tr))
 
-	ensureMarkerHook = func(any) {
-		once.Do(func() { close(markerReady) })
-		<-resume
-	}
-	defer func() { ensureMarkerHook = nil }()
+	m.Put(1, 1)
+	m.Put(2, 2)
 
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -162,14 +162,14 @@ func TestIteratorSkipsMarkersDuringConcurrentDeletion(t *testing.T) {
 		_, _ = m.Delete(1)
 	}()
 
-	<-markerReady
+	<-markReady
 
 	it := m.Iterator()
 	if !it.Next() {
 		t.Fatalf("expected iterator to yield successor during deletion")
 	}
 	if got := it.Key(); got != 2 {
-		t.Fatalf("expected iterator to skip deleted key and marker, got %d", got)
+		t.Fatalf("expected iterator to skip deleted key, got %d", got)
 	}
 
 	if it.Next() {
@@ -179,3 +179,170 @@ func TestIteratorSkipsMarkersDuringConcurrentDeletion(t *testing.T) {
 	close(resume)
 	wg.Wait()
 }
+
+func TestIteratorSeekLTAndSeekLE(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	for _, key := range []int{10, 20, 30} {
+		m.Put(key, key)
+	}
+
+	cases := []struct {
+		key    int
+		lt, le int
+		ltOK   bool
+		leOK   bool
+	}{
+		{key: 5},
+		{key: 10, le: 10, leOK: true},
+		{key: 15, lt: 10, ltOK: true, le: 10, leOK: true},
+		{key: 20, lt: 10, ltOK: true, le: 20, leOK: true},
+		{key: 35, lt: 30, ltOK: true, le: 30, leOK: true},
+	}
+
+	for _, tc := range cases {
+		it := m.Iterator()
+		if ok := it.SeekLT(tc.key); ok != tc.ltOK {
+			t.Fatalf("SeekLT(%d): expected ok=%v, got %v", tc.key, tc.ltOK, ok)
+		}
+		if tc.ltOK && it.Key() != tc.lt {
+			t.Fatalf("SeekLT(%d): expected key %d, got %d", tc.key, tc.lt, it.Key())
+		}
+
+		if ok := it.SeekLE(tc.key); ok != tc.leOK {
+			t.Fatalf("SeekLE(%d): expected ok=%v, got %v", tc.key, tc.leOK, ok)
+		}
+		if tc.leOK && it.Key() != tc.le {
+			t.Fatalf("SeekLE(%d): expected key %d, got %d", tc.key, tc.le, it.Key())
+		}
+	}
+}
+
+func TestIteratorPrevTraversesInReverse(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	for _, key := range []int{4, 2, 5, 1, 3} {
+		m.Put(key, key*10)
+	}
+
+	it := m.Iterator()
+	var keys []int
+	for it.Prev() {
+		if v := it.Value(); v != it.Key()*10 {
+			t.Fatalf("expected value %d for key %d, got %d", it.Key()*10, it.Key(), v)
+		}
+		keys = append(keys, it.Key())
+	}
+
+	expected := []int{5, 4, 3, 2, 1}
+	if !slices.Equal(keys, expected) {
+		t.Fatalf("expected reverse keys %v, got %v", expected, keys)
+	}
+	if it.Valid() {
+		t.Fatalf("expected iterator to be invalid after reverse exhaustion")
+	}
+
+	if !it.SeekGE(3) || !it.Prev() || it.Key() != 2 {
+		t.Fatalf("expected Prev after SeekGE(3) to land on key 2")
+	}
+	if !it.Next() || it.Key() != 3 {
+		t.Fatalf("expected Next after Prev to return to key 3")
+	}
+}
+
+func TestIteratorLastEmpty(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	it := m.Iterator()
+	if it.Last() {
+		t.Fatalf("expected Last on empty map to report false")
+	}
+	if it.Prev() {
+		t.Fatalf("expected Prev on empty map to report false")
+	}
+	if it.SeekLE(0) {
+		t.Fatalf("expected SeekLE on empty map to report false")
+	}
+}
+
+func TestIteratorBackwardSkipsLogicallyDeletedNodes(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	for i := 1; i <= 3; i++ {
+		m.Put(i, i)
+	}
+
+	for _, key := range []int{2, 3} {
+		_, succs, found := m.find(key)
+		if !found {
+			t.Fatalf("expected to locate key %d for deletion simulation", key)
+		}
+		succs[0].val.Store(nil)
+		m.metrics.AddLen(-1)
+	}
+
+	it := m.Iterator()
+	if !it.Last() {
+		t.Fatalf("expected Last to find a live element")
+	}
+	if got := it.Key(); got != 1 {
+		t.Fatalf("expected Last to skip deleted keys and yield 1, got %d", got)
+	}
+
+	if !it.SeekLE(3) || it.Key() != 1 {
+		t.Fatalf("expected SeekLE(3) to skip deleted keys and yield 1")
+	}
+	if it.Prev() {
+		t.Fatalf("expected Prev before the first element to report false")
+	}
+}
+
+func TestIteratorPrevDuringConcurrentWrites(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	const keys = 512
+	for i := range keys {
+		m.Put(i*2, i*2)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			k := (i % keys) * 2
+			m.Delete(k)
+			m.Put(k+1, k+1)
+			m.Put(k, k)
+			m.Delete(k + 1)
+		}
+	}()
+
+	for range 20 {
+		it := m.Iterator()
+		prev := keys * 2
+		for it.Prev() {
+			if it.Key() >= prev {
+				t.Fatalf("reverse iteration out of order: %d after %d", it.Key(), prev)
+			}
+			if it.Value() != it.Key() {
+				t.Fatalf("value mismatch for key %d: %d", it.Key(), it.Value())
+			}
+			prev = it.Key()
+		}
+	}
+
+	close(stop)
+	wg.Wait()
+}
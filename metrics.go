@@ -10,14 +10,58 @@ type metricShard struct {
 	insertCASRetries   atomic.Int64
 	insertCASSuccesses atomic.Int64
 	length             atomic.Int64
-	// Pad to cache line size to prevent false sharing.
-	_ [40]byte
+	deleteCASRetries   atomic.Int64
+	marks              atomic.Int64
+	helpUnlinks        atomic.Int64
+	upperLevelFailures atomic.Int64
+	searches           atomic.Int64
+	nodesVisited       atomic.Int64
+	// Pad to two cache lines to prevent false sharing.
+	_ [56]byte
+}
+
+// Stats is a snapshot of a map's counters, summed over all shards. The
+// shards are read one after another while writers keep counting, so the
+// fields need not agree with each other exactly.
+type Stats struct {
+	// InsertCASRetries counts failed or abandoned CAS attempts while
+	// linking new nodes, on any level.
+	InsertCASRetries int64
+	// InsertCASSuccesses counts nodes linked on level 0.
+	InsertCASSuccesses int64
+	// DeleteCASRetries counts CAS attempts lost by deletes, both on the value
+	// and while unlinking the node.
+	DeleteCASRetries int64
+	// Marks counts level-0 links marked to freeze a deleted node.
+	Marks int64
+	// HelpingUnlinks counts deleted nodes that a search unlinked on its way.
+	HelpingUnlinks int64
+	// UpperLevelFailures counts the times finishLevels had to search again
+	// before linking a tower above level 0.
+	UpperLevelFailures int64
+	// Searches counts descents from the head or a cursor, and NodesVisited
+	// the nodes whose keys they compared.
+	Searches     int64
+	NodesVisited int64
+	// Len is the number of live keys.
+	Len int64
+}
+
+// NodesPerSearch returns the average number of nodes a search visited.
+func (s Stats) NodesPerSearch() float64 {
+	if s.Searches == 0 {
+		return 0
+	}
+	return float64(s.NodesVisited) / float64(s.Searches)
 }
 
 type Metrics struct {
 	shards []metricShard
 	mask   uint32
 	rng    *RNG
+	// disabled turns the CAS counters into no-ops. The length counter is
+	// always maintained because LenInt64 depends on it.
+	disabled bool
 }
 
 func newMetrics(rng *RNG) *Metrics {
@@ -44,18 +88,62 @@ func (m *Metrics) shard() *metricShard {
 	if len(m.shards) == 1 || m.rng == nil {
 		return &m.shards[0]
 	}
-	idx := uint32(m.rng.nextRandom64()) & m.mask
+	idx := m.rng.nextShard() & m.mask
 	return &m.shards[idx]
 }
 
 func (m *Metrics) IncInsertCASRetry() {
+	if m.disabled {
+		return
+	}
 	m.shard().insertCASRetries.Add(1)
 }
 
 func (m *Metrics) IncInsertCASSuccess() {
+	if m.disabled {
+		return
+	}
 	m.shard().insertCASSuccesses.Add(1)
 }
 
+func (m *Metrics) IncDeleteCASRetry() {
+	if m.disabled {
+		return
+	}
+	m.shard().deleteCASRetries.Add(1)
+}
+
+func (m *Metrics) IncMark() {
+	if m.disabled {
+		return
+	}
+	m.shard().marks.Add(1)
+}
+
+func (m *Metrics) IncHelpUnlink() {
+	if m.disabled {
+		return
+	}
+	m.shard().helpUnlinks.Add(1)
+}
+
+func (m *Metrics) IncUpperLevelFailure() {
+	if m.disabled {
+		return
+	}
+	m.shard().upperLevelFailures.Add(1)
+}
+
+// AddSearch records one search that compared visited nodes.
+func (m *Metrics) AddSearch(visited int) {
+	if m.disabled {
+		return
+	}
+	s := m.shard()
+	s.searches.Add(1)
+	s.nodesVisited.Add(int64(visited))
+}
+
 func (m *Metrics) AddLen(d int64) {
 	m.shard().length.Add(d)
 }
@@ -76,3 +164,20 @@ func (m *Metrics) InsertCASStats() (int64, int64) {
 	}
 	return retries, successes
 }
+
+func (m *Metrics) Stats() Stats {
+	var st Stats
+	for i := range m.shards {
+		s := &m.shards[i]
+		st.InsertCASRetries += s.insertCASRetries.Load()
+		st.InsertCASSuccesses += s.insertCASSuccesses.Load()
+		st.DeleteCASRetries += s.deleteCASRetries.Load()
+		st.Marks += s.marks.Load()
+		st.HelpingUnlinks += s.helpUnlinks.Load()
+		st.UpperLevelFailures += s.upperLevelFailures.Load()
+		st.Searches += s.searches.Load()
+		st.NodesVisited += s.nodesVisited.Load()
+		st.Len += s.length.Load()
+	}
+	return st
+}
@@ -0,0 +1,97 @@
+package skiplist
+
+import "testing"
+
+func TestStatsCountsSearches(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+	for i := range 100 {
+		m.Put(i, i)
+	}
+
+	before := m.Stats()
+	for i := range 10 {
+		m.Get(i * 10)
+	}
+	after := m.Stats()
+
+	if got := after.Searches - before.Searches; got != 10 {
+		t.Fatalf("expected 10 searches, got %d", got)
+	}
+	if visited := after.NodesVisited - before.NodesVisited; visited < 10 {
+		t.Fatalf("expected each search to visit at least one node, got %d visits", visited)
+	}
+	if after.NodesPerSearch() <= 0 {
+		t.Fatalf("expected a positive average, got %f", after.NodesPerSearch())
+	}
+	if after.InsertCASSuccesses != 100 || after.Len != 100 {
+		t.Fatalf("expected 100 inserts and length 100, got %d and %d", after.InsertCASSuccesses, after.Len)
+	}
+}
+
+func TestStatsCountsMarksAndHelpingUnlinks(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := NewWithOptions[int, int](less, WithMaxLevel(1))
+	for i := 1; i <= 3; i++ {
+		m.Put(i, i)
+	}
+
+	// Delete 2 logically and leave it linked for the next search to unlink.
+	_, target, _ := m.search(2)
+	if _, ok := m.mutator.logicalDelete(target); !ok {
+		t.Fatal("expected logical delete to succeed")
+	}
+	before := m.Stats()
+	if _, ok := m.Get(3); !ok {
+		t.Fatal("expected key 3 to be present")
+	}
+	after := m.Stats()
+
+	if got := after.Marks - before.Marks; got != 1 {
+		t.Fatalf("expected the search to mark the deleted node once, got %d", got)
+	}
+	if got := after.HelpingUnlinks - before.HelpingUnlinks; got != 1 {
+		t.Fatalf("expected one helping unlink, got %d", got)
+	}
+
+	// Delete marks and unlinks by itself; nothing is left to help with.
+	m.Delete(1)
+	final := m.Stats()
+	if got := final.Marks - after.Marks; got != 1 {
+		t.Fatalf("expected Delete to mark once, got %d", got)
+	}
+	if final.HelpingUnlinks != after.HelpingUnlinks {
+		t.Fatalf("expected no helping unlinks from Delete, got %d", final.HelpingUnlinks-after.HelpingUnlinks)
+	}
+}
+
+func TestStatsCountsUpperLevelFailures(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	var m *SkipListMap[int, int]
+
+	// Replace the expected link on level 1 once, so finishLevels must search
+	// again before linking there. Keys are inserted in descending order, so
+	// the predecessor is always the head.
+	triggered := false
+	tr := &funcTracer{casAttempt: func(kind CASKind, _ any, level int) {
+		if kind != CASInsert || level != 1 || triggered {
+			return
+		}
+		triggered = true
+		l := m.head.next[1].Load()
+		m.head.next[1].Store(&link[int, int]{node: l.node})
+	}}
+	m = NewWithOptions[int, int](less, WithTracer(tr))
+
+	for i := 0; !triggered; i++ {
+		m.Put(-i, i)
+	}
+
+	st := m.Stats()
+	if st.UpperLevelFailures != 1 {
+		t.Fatalf("expected one upper-level failure, got %d", st.UpperLevelFailures)
+	}
+	if st.InsertCASRetries < st.UpperLevelFailures {
+		t.Fatalf("expected insert retries (%d) to include upper-level failures (%d)", st.InsertCASRetries, st.UpperLevelFailures)
+	}
+}
@@ -6,30 +6,63 @@ import "sync/atomic"
 type node[K, V any] struct {
 	key K
 	// val is a pointer to the value. A nil value indicates that the node is logically deleted.
-	val    atomic.Pointer[V]
-	next   []atomic.Pointer[*node[K, V]]
-	marker bool
+	// It is unused when the map stores values inline; see inlineNode.
+	val  atomic.Pointer[V]
+	next []atomic.Pointer[link[K, V]]
+}
+
+// link is an immutable successor reference held in node.next. Records are
+// shared and reinstalled: an unlink splices in the deleted node's frozen
+// link, upper levels reuse the link a node already holds, and an insert
+// hands the link it expected to the new node. Only unmarked records are
+// reinstalled, and a record always names the same successor, so a CAS that
+// succeeds against a reinstalled record still sees the unmarked successor its
+// caller validated.
+//
+// Deleting a node swaps its level-0 link for a marked copy. The copy freezes
+// the successor: an insert that expected the old link fails its CAS. It also
+// keeps the link it replaced, which helpers splice into the predecessor
+// without allocating.
+type link[K, V any] struct {
+	node *node[K, V]
+	// unmarked is the link a marked link replaced; it is nil on unmarked links.
+	unmarked *link[K, V]
+}
+
+func (l *link[K, V]) marked() bool {
+	return l.unmarked != nil
 }
 
 const (
+	// MaxLevel is the default and largest allowed maximum tower height.
 	MaxLevel = 32
-	P        = 1.0 / 2.0
+	// P is the default level promotion probability.
+	P = 1.0 / 2.0
 )
 
 func newNode[K, V any](key K, val *V, level int) *node[K, V] {
 	n := &node[K, V]{
 		key:  key,
-		next: make([]atomic.Pointer[*node[K, V]], level),
+		next: make([]atomic.Pointer[link[K, V]], level),
 	}
 	n.val.Store(val)
 	return n
 }
 
-func newSentinels[K, V any]() (*node[K, V], *node[K, V]) {
-	head := &node[K, V]{next: make([]atomic.Pointer[*node[K, V]], MaxLevel)}
-	tail := &node[K, V]{}
+// newSentinels returns the head and tail sentinels and the link to the tail
+// that every level of the head starts with. With inline set they are laid
+// out as inline nodes, like every other node of such a map.
+func newSentinels[K, V any](maxLevel int, inline bool) (*node[K, V], *node[K, V], *link[K, V]) {
+	var head, tail *node[K, V]
+	if inline {
+		head, tail = &new(inlineNode[K, V]).node, &new(inlineNode[K, V]).node
+	} else {
+		head, tail = &node[K, V]{}, &node[K, V]{}
+	}
+	head.next = make([]atomic.Pointer[link[K, V]], maxLevel)
+	toTail := &link[K, V]{node: tail}
 	for i := range head.next {
-		head.next[i].Store(&tail)
+		head.next[i].Store(toTail)
 	}
-	return head, tail
+	return head, tail, toTail
 }
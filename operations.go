@@ -1,31 +1,92 @@
 package skiplist
 
-import "sync/atomic"
-
 // mutatorImpl groups the mutating algorithms.
-type mutatorImpl[K comparable, V any] struct {
+type mutatorImpl[K, V any] struct {
 	m *SkipListMap[K, V]
 }
 
+// searchBuf holds the predecessors and successors of one search. It is sized
+// for the tallest allowed tower so that mutators can keep it on the stack and
+// retry without allocating.
+type searchBuf[K, V any] struct {
+	preds [MaxLevel]*node[K, V]
+	succs [MaxLevel]*node[K, V]
+}
+
+// remember records n as the predecessor on every level of its tower, so that
+// a hinted search for a larger key starts right behind it.
+func (b *searchBuf[K, V]) remember(n *node[K, V]) {
+	for i := range n.next {
+		b.preds[i] = n
+	}
+}
+
+// find runs findInto over buf and returns views of its arrays.
+func (u *mutatorImpl[K, V]) find(key K, buf *searchBuf[K, V]) (preds, succs []*node[K, V], found bool) {
+	preds, succs = buf.preds[:u.m.maxLevel], buf.succs[:u.m.maxLevel]
+	found = u.m.findInto(key, preds, succs)
+	return preds, succs, found
+}
+
 // put inserts or updates the value for the given key in the skiplist.
 // It returns the previous value and true if the key existed, otherwise zero value and false.
 func (u *mutatorImpl[K, V]) put(key K, value V) (V, bool) {
-	var pendingPtr **node[K, V]
+	return u.insert(key, value, true)
+}
+
+// putIfAbsent inserts the value only if the key has no live entry. It returns
+// the existing value and true if the key was present, otherwise the inserted
+// value and false.
+func (u *mutatorImpl[K, V]) putIfAbsent(key K, value V) (V, bool) {
+	if existing, loaded := u.insert(key, value, false); loaded {
+		return existing, true
+	}
+	return value, false
+}
+
+// insert links a new node for key, or handles an existing live node by
+// swapping in value when replace is set and leaving it untouched otherwise.
+// It returns the value observed in the existing node and true if one was
+// found, otherwise zero value and false.
+func (u *mutatorImpl[K, V]) insert(key K, value V, replace bool) (V, bool) {
+	var buf searchBuf[K, V]
+	return u.insertFrom(key, value, replace, &buf, false)
+}
+
+// insertFrom is insert with a caller-owned search buffer, which holds the
+// last search on return. If hinted is set, buf already holds a search for a
+// smaller key and the first search starts from it; retries search from the
+// head.
+func (u *mutatorImpl[K, V]) insertFrom(key K, value V, replace bool, buf *searchBuf[K, V], hinted bool) (V, bool) {
+	// toPending links to the node this call has linked on level 0 but not yet
+	// on every level of its tower.
+	var toPending *link[K, V]
 	nextLevel := 1
+	cloned := false
 
 	for {
-		preds, succs, found := u.m.find(key)
-
-		if pendingPtr != nil {
-			pending := *pendingPtr
+		var preds, succs []*node[K, V]
+		var found bool
+		if hinted {
+			hinted = false
+			preds, succs = buf.preds[:u.m.maxLevel], buf.succs[:u.m.maxLevel]
+			var ok bool
+			if found, ok = u.m.findFrom(key, preds, succs); !ok {
+				preds, succs, found = u.find(key, buf)
+			}
+		} else {
+			preds, succs, found = u.find(key, buf)
+		}
 
-			if succs[0] != pending {
+		if toPending != nil {
+			if succs[0] != toPending.node {
 				var zero V
 				return zero, false
 			}
 
-			done, resumeLevel := u.finishLevels(preds, succs, pendingPtr, nextLevel)
+			done, resumeLevel := u.finishLevels(preds, succs, toPending, nextLevel)
 			if done {
+				buf.remember(toPending.node)
 				var zero V
 				return zero, false
 			}
@@ -37,23 +98,31 @@ func (u *mutatorImpl[K, V]) put(key K, value V) (V, bool) {
 		if found {
 			node := succs[0]
 			for {
-				oldPtr := node.val.Load()
-				if oldPtr == nil {
-					markerPtr := u.ensureMarker(node)
-					u.physicalDelete(preds, node, markerPtr)
+				old, ref, ok := u.m.loadValue(node)
+				if !ok {
+					u.physicalDelete(preds, node, u.ensureMarked(node))
 					break
 				}
-				if node.val.CompareAndSwap(oldPtr, &value) {
-					return *oldPtr, true
+				if !replace {
+					return old, true
+				}
+				if u.m.swapValue(node, ref, value) {
+					return old, true
 				}
 			}
 			continue
 		}
 
+		if u.m.cloneKey != nil && !cloned {
+			// Copy once; a retry reuses the copy.
+			key, cloned = u.m.cloneKey(key), true
+		}
 		height := u.m.rng.RandomLevel()
-		valCopy := value
-		newNode := newNode(key, &valCopy, height)
-		pendingPtr = &newNode
+		if u.m.tracer != nil {
+			u.m.tracer.LevelChosen(height)
+		}
+		u.m.raiseHeight(height)
+		newNode := u.m.newNode(key, value, height)
 		nextLevel = 1
 
 		pred0 := preds[0]
@@ -62,31 +131,16 @@ func (u *mutatorImpl[K, V]) put(key K, value V) (V, bool) {
 		}
 
 		expected0 := pred0.next[0].Load()
-		succNode0 := succs[0]
-		succPtr0 := expected0
-		if succPtr0 == nil {
-			succPtr0 = &u.m.tail
-		}
-
-		if succNode0 != nil && succNode0 != u.m.tail {
-			if expected0 == nil || *expected0 != succNode0 {
-				u.m.metrics.IncInsertCASRetry()
-				pendingPtr = nil
-				continue
-			}
-		} else {
-			if expected0 != nil && *expected0 != u.m.tail {
-				u.m.metrics.IncInsertCASRetry()
-				pendingPtr = nil
-				continue
-			}
+		if expected0.marked() || expected0.node != succs[0] {
+			u.m.metrics.IncInsertCASRetry()
+			continue
 		}
 
-		newNode.next[0].Store(succPtr0)
+		newNode.next[0].Store(expected0)
 
-		if !pred0.next[0].CompareAndSwap(expected0, pendingPtr) {
+		toNew := &link[K, V]{node: newNode}
+		if !u.m.cas(CASInsert, key, 0, &pred0.next[0], expected0, toNew) {
 			u.m.metrics.IncInsertCASRetry()
-			pendingPtr = nil
 			continue
 		}
 
@@ -94,13 +148,15 @@ func (u *mutatorImpl[K, V]) put(key K, value V) (V, bool) {
 		u.m.metrics.AddLen(1)
 
 		if height == 1 {
-			pendingPtr = nil
+			buf.remember(newNode)
 			var zero V
 			return zero, false
 		}
 
-		done, resumeLevel := u.finishLevels(preds, succs, pendingPtr, nextLevel)
+		toPending = toNew
+		done, resumeLevel := u.finishLevels(preds, succs, toPending, nextLevel)
 		if done {
+			buf.remember(newNode)
 			var zero V
 			return zero, false
 		}
@@ -111,12 +167,12 @@ func (u *mutatorImpl[K, V]) put(key K, value V) (V, bool) {
 
 // finishLevels completes the insertion of a new node at higher levels in the skiplist.
 // It returns true if done, and the next level to resume from.
-func (u *mutatorImpl[K, V]) finishLevels(preds, succs []*node[K, V], pendingPtr **node[K, V], nextLevel int) (bool, int) {
-	if pendingPtr == nil {
+func (u *mutatorImpl[K, V]) finishLevels(preds, succs []*node[K, V], toPending *link[K, V], nextLevel int) (bool, int) {
+	if toPending == nil {
 		return true, 0
 	}
 
-	pending := *pendingPtr
+	pending := toPending.node
 
 	height := len(pending.next)
 	for level := nextLevel; level < height; level++ {
@@ -127,110 +183,75 @@ func (u *mutatorImpl[K, V]) finishLevels(preds, succs []*node[K, V], pendingPtr
 		if level >= len(pred.next) {
 			// The predecessor we observed no longer has this level; retry.
 			u.m.metrics.IncInsertCASRetry()
+			u.m.metrics.IncUpperLevelFailure()
 			return false, level
 		}
 
 		expected := pred.next[level].Load()
-		succNode := succs[level]
-		succPtr := expected
-		if succPtr == nil {
-			succPtr = &u.m.tail
-		}
-
-		if succNode != nil && succNode != u.m.tail {
-			if expected == nil || *expected != succNode {
-				// The snapshot at this level is stale; retry the insertion.
-				u.m.metrics.IncInsertCASRetry()
-				return false, level
-			}
-		} else {
-			if expected != nil && *expected != u.m.tail {
-				u.m.metrics.IncInsertCASRetry()
-				return false, level
-			}
+		succ := succs[level]
+		if expected.node != succ || succ != u.m.tail && u.m.compare(pending.key, succ.key) >= 0 {
+			// The snapshot at this level is stale; retry the insertion.
+			u.m.metrics.IncInsertCASRetry()
+			u.m.metrics.IncUpperLevelFailure()
+			return false, level
 		}
 
-		pending.next[level].Store(succPtr)
-
-		if putLevelCASHook != nil {
-			putLevelCASHook(level, pred, expected, pendingPtr)
-		}
+		pending.next[level].Store(expected)
 
-		if !pred.next[level].CompareAndSwap(expected, pendingPtr) {
+		if !u.m.cas(CASInsert, pending.key, level, &pred.next[level], expected, toPending) {
 			u.m.metrics.IncInsertCASRetry()
+			u.m.metrics.IncUpperLevelFailure()
 			return false, level
 		}
 	}
 
-	pendingPtr = nil
 	return true, len(pending.next)
 }
 
 // logicalDelete marks the value of the target node as deleted.
 // It returns the old value and true if successful, otherwise zero value and false.
 func (u *mutatorImpl[K, V]) logicalDelete(target *node[K, V]) (V, bool) {
+	return u.logicalDeleteIf(target, nil)
+}
+
+// logicalDeleteIf behaves like logicalDelete, but when match is non-nil it
+// only deletes a value that match accepts.
+func (u *mutatorImpl[K, V]) logicalDeleteIf(target *node[K, V], match func(V) bool) (V, bool) {
 	var zero V
 	if target == nil {
 		return zero, false
 	}
 	for {
-		cur := target.val.Load()
-		if cur == nil {
+		cur, ref, ok := u.m.loadValue(target)
+		if !ok {
+			return zero, false
+		}
+		if match != nil && !match(cur) {
 			return zero, false
 		}
-		if target.val.CompareAndSwap(cur, nil) {
+		if u.m.deleteValue(target, ref) {
 			u.m.metrics.AddLen(-1)
-			return *cur, true
+			return cur, true
 		}
+		u.m.metrics.IncDeleteCASRetry()
 	}
 }
 
-// ensureMarker ensures a marker node is placed after the target node for deletion.
-// It returns a pointer to the marker node.
-func (u *mutatorImpl[K, V]) ensureMarker(target *node[K, V]) **node[K, V] {
-	for {
-		nextPtr := target.next[0].Load()
-		succPtr := nextPtr
-		if succPtr == nil {
-			succPtr = &u.m.tail
-		}
-		nextNode := *succPtr
-		if nextNode.marker {
-			return nextPtr
-		}
-		marker := &node[K, V]{key: target.key, next: make([]atomic.Pointer[*node[K, V]], 1), marker: true}
-		marker.next[0].Store(succPtr)
-		markerPtr := &marker
-		if target.next[0].CompareAndSwap(nextPtr, markerPtr) {
-			if ensureMarkerHook != nil {
-				ensureMarkerHook(target)
-			}
-			return markerPtr
-		}
-	}
+// ensureMarked marks the target's level-0 link so that nothing can be
+// inserted after it. It returns the marked link.
+func (u *mutatorImpl[K, V]) ensureMarked(target *node[K, V]) *link[K, V] {
+	marked, _ := u.m.mark(target)
+	return marked
 }
 
 // physicalDelete removes the target node from the skiplist at all levels.
 // It returns true if the deletion should be retried.
-func (u *mutatorImpl[K, V]) physicalDelete(preds []*node[K, V], target *node[K, V], markerPtr **node[K, V]) bool {
-	succPtr0 := &u.m.tail
-	if markerPtr != nil {
-		if marker := *markerPtr; marker != nil && marker.marker {
-			if next := marker.next[0].Load(); next != nil {
-				succPtr0 = next
-			}
-		}
-	}
-
+func (u *mutatorImpl[K, V]) physicalDelete(preds []*node[K, V], target *node[K, V], marked *link[K, V]) bool {
 	topLevel := len(target.next) - 1
 	for level := topLevel; level >= 0; level-- {
-		succPtr := succPtr0
+		succ := marked.unmarked
 		if level > 0 {
-			if next := target.next[level].Load(); next != nil {
-				succPtr = next
-			} else {
-				succPtr = &u.m.tail
-			}
+			succ = u.m.loadNextPtr(target, level)
 		}
 
 		pred := preds[level]
@@ -244,22 +265,15 @@ func (u *mutatorImpl[K, V]) physicalDelete(preds []*node[K, V], target *node[K,
 			}
 
 			current := pred.next[level].Load()
-
-			var expectedNode *node[K, V]
-			if current == nil {
-				expectedNode = u.m.tail
-			} else {
-				expectedNode = *current
+			// A marked predecessor is being deleted itself; its link must
+			// stay frozen.
+			if current.node != target || current.marked() {
+				break
 			}
-
-			if expectedNode == target || (level == 0 && expectedNode != nil && expectedNode.marker) {
-				if pred.next[level].CompareAndSwap(current, succPtr) {
-					break
-				}
-				continue
+			if u.m.cas(CASUnlink, target.key, level, &pred.next[level], current, succ) {
+				break
 			}
-
-			break
+			u.m.metrics.IncDeleteCASRetry()
 		}
 	}
 
@@ -271,48 +285,218 @@ func (u *mutatorImpl[K, V]) physicalDelete(preds []*node[K, V], target *node[K,
 		return false
 	}
 
-	nextPtr := pred0.next[0].Load()
-	if nextPtr == nil {
+	return pred0.next[0].Load().node == target
+}
+
+// delete removes the key-value pair for the given key from the skiplist.
+// It returns the old value and true if the key existed, otherwise zero value and false.
+func (u *mutatorImpl[K, V]) delete(key K) (V, bool) {
+	var buf searchBuf[K, V]
+	preds, succs, found := u.find(key, &buf)
+	if !found {
+		var zero V
+		return zero, false
+	}
+
+	target := succs[0]
+	oldVal, ok := u.logicalDelete(target)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	u.unlink(key, preds, target)
+	return oldVal, true
+}
+
+// compareAndSwap replaces the value for key with newValue if the live value
+// equals old according to eq. It reports whether the swap happened.
+func (u *mutatorImpl[K, V]) compareAndSwap(key K, old, newValue V, eq func(a, b V) bool) bool {
+	var buf searchBuf[K, V]
+	for {
+		preds, succs, found := u.find(key, &buf)
+		if !found {
+			return false
+		}
+
+		node := succs[0]
+		for {
+			cur, ref, ok := u.m.loadValue(node)
+			if !ok {
+				// Deleted after the search observed it; help unlink and
+				// search again in case the key was re-inserted.
+				u.physicalDelete(preds, node, u.ensureMarked(node))
+				break
+			}
+			if !eq(cur, old) {
+				return false
+			}
+			if u.m.swapValue(node, ref, newValue) {
+				return true
+			}
+		}
+	}
+}
+
+// compareAndDelete removes the entry for key if its live value equals old
+// according to eq. It reports whether the entry was removed.
+func (u *mutatorImpl[K, V]) compareAndDelete(key K, old V, eq func(a, b V) bool) bool {
+	var buf searchBuf[K, V]
+	preds, succs, found := u.find(key, &buf)
+	if !found {
 		return false
 	}
 
-	nextNode := *nextPtr
-	if nextNode == target || nextNode.marker {
-		return true
+	target := succs[0]
+	if _, ok := u.logicalDeleteIf(target, func(cur V) bool { return eq(cur, old) }); !ok {
+		return false
 	}
+	u.unlink(key, preds, target)
+	return true
+}
 
-	return false
+// unlink runs the mark and physical phases for a target that this caller
+// has already logically deleted.
+func (u *mutatorImpl[K, V]) unlink(key K, preds []*node[K, V], target *node[K, V]) {
+	if retry := u.physicalDelete(preds, target, u.ensureMarked(target)); retry {
+		// The predecessor changed under us; a fresh search helps finish the
+		// unlink. The delete itself already linearized at the value CAS.
+		u.m.search(key)
+	}
 }
 
-// delete removes the key-value pair for the given key from the skiplist.
-// It returns the old value and true if the key existed, otherwise zero value and false.
-func (u *mutatorImpl[K, V]) delete(key K) (V, bool) {
+// compute applies fn to the current entry for key and stores its result,
+// retrying with a CAS on the node value until no other writer intervenes.
+// Absent keys are inserted through the put path without replacing a racing
+// insert. It returns the resulting value and whether the key is present.
+func (u *mutatorImpl[K, V]) compute(key K, fn func(old V, exists bool) (V, Op)) (V, bool) {
+	var buf searchBuf[K, V]
+	var zero V
 	for {
-		preds, succs, found := u.m.find(key)
+		preds, succs, found := u.find(key, &buf)
 		if !found {
-			var zero V
-			return zero, false
+			newValue, op := fn(zero, false)
+			if op != OpUpdate {
+				return zero, false
+			}
+			if _, loaded := u.insert(key, newValue, false); loaded {
+				// Another writer inserted the key first; recompute from it.
+				continue
+			}
+			return newValue, true
 		}
 
-		target := succs[0]
-		oldVal, ok := u.logicalDelete(target)
-		if !ok {
-			var zero V
-			return zero, false
+		node := succs[0]
+		for {
+			cur, ref, ok := u.m.loadValue(node)
+			if !ok {
+				u.physicalDelete(preds, node, u.ensureMarked(node))
+				break
+			}
+
+			newValue, op := fn(cur, true)
+			switch op {
+			case OpUpdate:
+				if u.m.swapValue(node, ref, newValue) {
+					return newValue, true
+				}
+			case OpDelete:
+				if u.m.deleteValue(node, ref) {
+					u.m.metrics.AddLen(-1)
+					u.unlink(key, preds, node)
+					return zero, false
+				}
+			default:
+				return cur, true
+			}
 		}
-		markerPtr := u.ensureMarker(target)
+	}
+}
 
-		if retry := u.physicalDelete(preds, target, markerPtr); retry {
-			continue
+// popMin claims the smallest live entry through the logicalDelete CAS. When
+// another goroutine wins the CAS for a node, it skips ahead to that node's
+// successor instead of restarting from the head.
+func (u *mutatorImpl[K, V]) popMin() (K, V, bool) {
+	var buf searchBuf[K, V]
+	var start *node[K, V]
+	for {
+		target := u.m.advanceFrom(start)
+		if target == nil {
+			var zeroK K
+			var zeroV V
+			return zeroK, zeroV, false
 		}
 
-		if _, _, verifyFound := u.m.find(key); verifyFound {
-			// A concurrent insertion added the key back before the delete
-			// could finish. Retry the removal so the delete only reports
-			// success once the key is absent.
-			continue
+		if val, ok := u.logicalDelete(target); ok {
+			preds, _, _ := u.find(target.key, &buf)
+			u.unlink(target.key, preds, target)
+			return target.key, val, true
 		}
+		start = target
+	}
+}
 
-		return oldVal, true
+// popMax claims the largest live entry through the logicalDelete CAS,
+// searching for the last node again whenever another goroutine wins the CAS.
+func (u *mutatorImpl[K, V]) popMax() (K, V, bool) {
+	var buf searchBuf[K, V]
+	for {
+		target := u.m.findLast()
+		if target == nil || target == u.m.head {
+			var zeroK K
+			var zeroV V
+			return zeroK, zeroV, false
+		}
+
+		if val, ok := u.logicalDelete(target); ok {
+			preds, _, _ := u.find(target.key, &buf)
+			u.unlink(target.key, preds, target)
+			return target.key, val, true
+		}
 	}
 }
+
+// deleteRange removes every live key in [lo, hi) and returns how many entries
+// it removed. It walks level 0 once, logically deleting each live node, then
+// marks them all and lets a single search from lo unlink the whole run of
+// deleted nodes on every level. Each key's removal linearizes at its own
+// value CAS.
+func (u *mutatorImpl[K, V]) deleteRange(lo, hi K) int {
+	if u.m.compare(lo, hi) >= 0 {
+		return 0
+	}
+
+	_, first, _ := u.m.search(lo)
+	var victims []*node[K, V]
+	for n := first; n != u.m.tail && u.m.compare(n.key, hi) < 0; n = n.next[0].Load().node {
+		if _, ok := u.logicalDelete(n); ok {
+			victims = append(victims, n)
+		}
+	}
+	if len(victims) == 0 {
+		return 0
+	}
+
+	for _, victim := range victims {
+		u.ensureMarked(victim)
+	}
+
+	// A live node inserted inside the range ends the run that one search can
+	// unlink; resume from the next victim behind it until none are left.
+	key := lo
+	idx := 0
+	for {
+		_, succ, _ := u.m.search(key)
+		if succ == nil || succ == u.m.tail || u.m.compare(succ.key, hi) >= 0 {
+			break
+		}
+		for idx < len(victims) && u.m.compare(succ.key, victims[idx].key) >= 0 {
+			idx++
+		}
+		if idx == len(victims) {
+			break
+		}
+		key = victims[idx].key
+	}
+
+	return len(victims)
+}
@@ -0,0 +1,122 @@
+package skiplist
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestFirstLastEmpty(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	if _, _, ok := m.First(); ok {
+		t.Fatalf("expected First on empty map to report false")
+	}
+	if _, _, ok := m.Last(); ok {
+		t.Fatalf("expected Last on empty map to report false")
+	}
+	if _, _, ok := m.PopMin(); ok {
+		t.Fatalf("expected PopMin on empty map to report false")
+	}
+	if _, _, ok := m.PopMax(); ok {
+		t.Fatalf("expected PopMax on empty map to report false")
+	}
+}
+
+func TestPopMinAndPopMaxOrder(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	for _, key := range []int{3, 1, 4, 5, 2} {
+		m.Put(key, key*10)
+	}
+
+	if k, v, ok := m.First(); !ok || k != 1 || v != 10 {
+		t.Fatalf("expected First to return (1, 10), got (%d, %d, %v)", k, v, ok)
+	}
+	if k, v, ok := m.Last(); !ok || k != 5 || v != 50 {
+		t.Fatalf("expected Last to return (5, 50), got (%d, %d, %v)", k, v, ok)
+	}
+
+	var popped []int
+	for range 2 {
+		k, v, ok := m.PopMin()
+		if !ok || v != k*10 {
+			t.Fatalf("expected PopMin to return a live entry, got (%d, %d, %v)", k, v, ok)
+		}
+		popped = append(popped, k)
+	}
+	for {
+		k, _, ok := m.PopMax()
+		if !ok {
+			break
+		}
+		popped = append(popped, k)
+	}
+
+	if want := []int{1, 2, 5, 4, 3}; !slices.Equal(popped, want) {
+		t.Fatalf("expected pop order %v, got %v", want, popped)
+	}
+	if gotLen := m.LenInt64(); gotLen != 0 {
+		t.Fatalf("expected length 0 after draining, got %d", gotLen)
+	}
+	if it := m.Iterator(); it.Next() {
+		t.Fatalf("expected no keys after draining, found %d", it.Key())
+	}
+}
+
+func TestPopConcurrentConsumersClaimOnce(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	const total = 4096
+	for i := range total {
+		m.Put(i, i)
+	}
+
+	const consumers = 8
+	results := make([][]int, consumers)
+
+	var wg sync.WaitGroup
+	for c := range consumers {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			pop := m.PopMin
+			if c%2 == 1 {
+				pop = m.PopMax
+			}
+			for {
+				k, v, ok := pop()
+				if !ok {
+					return
+				}
+				if k != v {
+					t.Errorf("value mismatch for key %d: %d", k, v)
+					return
+				}
+				results[c] = append(results[c], k)
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	seen := make([]bool, total)
+	count := 0
+	for _, keys := range results {
+		for _, k := range keys {
+			if seen[k] {
+				t.Fatalf("key %d popped more than once", k)
+			}
+			seen[k] = true
+			count++
+		}
+	}
+	if count != total {
+		t.Fatalf("expected %d popped keys, got %d", total, count)
+	}
+	if gotLen := m.LenInt64(); gotLen != 0 {
+		t.Fatalf("expected length 0 after draining, got %d", gotLen)
+	}
+}
@@ -2,56 +2,76 @@ package skiplist
 
 import (
 	"math/bits"
-	"math/rand"
-	"sync"
-	"time"
+	"math/rand/v2"
+	"sync/atomic"
 )
 
+// RNG draws tower heights. An unseeded RNG uses the runtime's per-thread
+// generator behind math/rand/v2, so drawing needs no locks or pooling. A
+// seeded RNG runs SplitMix64 over an atomic counter: the sequence is
+// reproducible, and concurrent callers each take a distinct step of it.
 type RNG struct {
-	pool sync.Pool
-	once sync.Once
+	seeded bool
+	state  atomic.Uint64
+	// maxLevel and p shape RandomLevel; zero values select MaxLevel and P.
+	maxLevel int
+	p        float64
 }
 
 func newRNG() *RNG {
-	r := &RNG{}
-	r.pool.New = func() any {
-		return rand.New(rand.NewSource(time.Now().UnixNano()))
-	}
-	// Pre-warm the pool
-	r.pool.Put(r.pool.New())
-	return r
+	return &RNG{}
 }
 
 func newRNGWithSeed(seed int64) *RNG {
-	r := &RNG{}
-	r.pool.New = func() any {
-		return rand.New(rand.NewSource(seed))
-	}
+	r := &RNG{seeded: true}
+	r.state.Store(uint64(seed))
 	return r
 }
 
-func (r *RNG) ensurePool() {
-	r.once.Do(func() {
-		if r.pool.New == nil {
-			r.pool.New = func() any {
-				return rand.New(rand.NewSource(time.Now().UnixNano()))
-			}
-		}
-	})
-}
+// splitMixGamma is the SplitMix64 counter increment.
+const splitMixGamma = 0x9e3779b97f4a7c15
 
 func (r *RNG) nextRandom64() uint64 {
-	r.ensurePool()
-	rr := r.pool.Get().(*rand.Rand)
-	v := rr.Uint64()
-	r.pool.Put(rr)
-	return v
+	if !r.seeded {
+		return rand.Uint64()
+	}
+	z := r.state.Add(splitMixGamma)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// nextShard returns a random value for picking a metrics shard. It always
+// uses the runtime generator: shard choice need not be reproducible, and
+// keeping it off the seeded sequence leaves tower heights independent of how
+// many counters were bumped.
+func (r *RNG) nextShard() uint32 {
+	return rand.Uint32()
 }
 
+const float64Unit = 1.0 / (1 << 53)
+
 func (r *RNG) RandomLevel() int {
-	level := bits.TrailingZeros64(r.nextRandom64()) + 1
-	if level > MaxLevel {
-		return MaxLevel
+	maxLevel := r.maxLevel
+	if maxLevel <= 0 {
+		maxLevel = MaxLevel
+	}
+	p := r.p
+	if p == 0 {
+		p = P
+	}
+
+	if p == 0.5 {
+		level := bits.TrailingZeros64(r.nextRandom64()) + 1
+		if level > maxLevel {
+			return maxLevel
+		}
+		return level
+	}
+
+	level := 1
+	for level < maxLevel && float64(r.nextRandom64()>>11)*float64Unit < p {
+		level++
 	}
 	return level
 }
@@ -42,9 +42,39 @@ func TestRandomLevelDistribution(t *testing.T) {
 	}
 }
 
-func BenchmarkRNGPoolInit(b *testing.B) {
-	for i := 0; i < b.N; i++ {
-		rng := newRNG()
-		rng.nextRandom64() // This will trigger pool.New on first call
+func TestSeededRNGIsReproducible(t *testing.T) {
+	a := newRNGWithSeed(42)
+	b := newRNGWithSeed(42)
+	c := newRNGWithSeed(43)
+
+	same := true
+	for i := range 64 {
+		x, y, z := a.nextRandom64(), b.nextRandom64(), c.nextRandom64()
+		if x != y {
+			t.Fatalf("draw %d: expected equal seeds to agree, got %x and %x", i, x, y)
+		}
+		same = same && x == z
+	}
+	if same {
+		t.Fatalf("expected different seeds to produce different sequences")
 	}
 }
+
+func BenchmarkRandomLevel(b *testing.B) {
+	b.Run("Runtime", func(b *testing.B) {
+		rng := newRNG()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				rng.RandomLevel()
+			}
+		})
+	})
+	b.Run("Seeded", func(b *testing.B) {
+		rng := newRNGWithSeed(1)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				rng.RandomLevel()
+			}
+		})
+	})
+}
@@ -0,0 +1,178 @@
+package skiplist
+
+// BoundKind describes how a Bound constrains one end of a range.
+type BoundKind int
+
+const (
+	// Unbounded leaves the end of the range open.
+	Unbounded BoundKind = iota
+	// Inclusive includes the bound key in the range.
+	Inclusive
+	// Exclusive excludes the bound key from the range.
+	Exclusive
+)
+
+// Bound is one end of a key range.
+type Bound[K any] struct {
+	Key  K
+	Kind BoundKind
+}
+
+// Included returns a bound that includes key.
+func Included[K any](key K) Bound[K] {
+	return Bound[K]{Key: key, Kind: Inclusive}
+}
+
+// Excluded returns a bound that excludes key.
+func Excluded[K any](key K) Bound[K] {
+	return Bound[K]{Key: key, Kind: Exclusive}
+}
+
+// Unbound returns a bound that leaves its end of the range open.
+func Unbound[K any]() Bound[K] {
+	return Bound[K]{Kind: Unbounded}
+}
+
+// RangeIterator walks the elements whose keys fall between a lower and an
+// upper bound. It stops by itself at either bound, in both directions.
+type RangeIterator[K, V any] struct {
+	it    Iterator[K, V]
+	lower Bound[K]
+	upper Bound[K]
+}
+
+// Range returns an iterator over the elements between lower and upper,
+// positioned before the first element. Calling Next walks the range in
+// ascending order; calling Prev walks it in descending order.
+func (m *SkipListMap[K, V]) Range(lower, upper Bound[K]) *RangeIterator[K, V] {
+	return &RangeIterator[K, V]{
+		it:    Iterator[K, V]{m: m},
+		lower: lower,
+		upper: upper,
+	}
+}
+
+// Valid reports whether the iterator currently points at an element.
+func (r *RangeIterator[K, V]) Valid() bool {
+	if r == nil {
+		return false
+	}
+	return r.it.Valid()
+}
+
+// Key returns the key at the iterator's current position.
+// It should only be called when Valid reports true.
+func (r *RangeIterator[K, V]) Key() K {
+	var zero K
+	if r == nil {
+		return zero
+	}
+	return r.it.Key()
+}
+
+// Value returns the value at the iterator's current position.
+// It should only be called when Valid reports true.
+func (r *RangeIterator[K, V]) Value() V {
+	var zero V
+	if r == nil {
+		return zero
+	}
+	return r.it.Value()
+}
+
+// First positions the iterator at the smallest element in the range. It
+// returns true if the range is not empty.
+func (r *RangeIterator[K, V]) First() bool {
+	if r == nil || r.it.m == nil {
+		return false
+	}
+
+	switch r.lower.Kind {
+	case Inclusive:
+		r.it.SeekGE(r.lower.Key)
+	case Exclusive:
+		if r.it.SeekGE(r.lower.Key) && r.it.m.compare(r.lower.Key, r.it.key) >= 0 {
+			r.it.Next()
+		}
+	default:
+		r.it.invalidate()
+		r.it.Next()
+	}
+	return r.clipUpper()
+}
+
+// Last positions the iterator at the largest element in the range. It
+// returns true if the range is not empty.
+func (r *RangeIterator[K, V]) Last() bool {
+	if r == nil || r.it.m == nil {
+		return false
+	}
+
+	switch r.upper.Kind {
+	case Inclusive:
+		r.it.SeekLE(r.upper.Key)
+	case Exclusive:
+		r.it.SeekLT(r.upper.Key)
+	default:
+		r.it.Last()
+	}
+	return r.clipLower()
+}
+
+// Next advances the iterator to the next element in the range and reports
+// whether it moved. If the iterator was not valid prior to the call, it moves
+// to the first element in the range.
+func (r *RangeIterator[K, V]) Next() bool {
+	if r == nil || r.it.m == nil {
+		return false
+	}
+	if !r.it.valid {
+		return r.First()
+	}
+	r.it.Next()
+	return r.clipUpper()
+}
+
+// Prev moves the iterator to the previous element in the range and reports
+// whether it moved. If the iterator was not valid prior to the call, it moves
+// to the last element in the range.
+func (r *RangeIterator[K, V]) Prev() bool {
+	if r == nil || r.it.m == nil {
+		return false
+	}
+	if !r.it.valid {
+		return r.Last()
+	}
+	r.it.Prev()
+	return r.clipLower()
+}
+
+// clipUpper invalidates the iterator if it moved past the upper bound.
+func (r *RangeIterator[K, V]) clipUpper() bool {
+	if !r.it.valid {
+		return false
+	}
+	if r.upper.Kind == Unbounded {
+		return true
+	}
+	c := r.it.m.compare(r.it.key, r.upper.Key)
+	if c > 0 || c == 0 && r.upper.Kind == Exclusive {
+		r.it.invalidate()
+	}
+	return r.it.valid
+}
+
+// clipLower invalidates the iterator if it moved before the lower bound.
+func (r *RangeIterator[K, V]) clipLower() bool {
+	if !r.it.valid {
+		return false
+	}
+	if r.lower.Kind == Unbounded {
+		return true
+	}
+	c := r.it.m.compare(r.it.key, r.lower.Key)
+	if c < 0 || c == 0 && r.lower.Kind == Exclusive {
+		r.it.invalidate()
+	}
+	return r.it.valid
+}
@@ -0,0 +1,109 @@
+package skiplist
+
+import (
+	"slices"
+	"testing"
+)
+
+func collectRange(r *RangeIterator[int, int], backward bool) []int {
+	var keys []int
+	step := r.Next
+	if backward {
+		step = r.Prev
+	}
+	for step() {
+		keys = append(keys, r.Key())
+	}
+	return keys
+}
+
+func TestRangeBounds(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	for i := 1; i <= 9; i += 2 {
+		m.Put(i, i)
+	}
+
+	cases := []struct {
+		name         string
+		lower, upper Bound[int]
+		want         []int
+	}{
+		{name: "unbounded", lower: Unbound[int](), upper: Unbound[int](), want: []int{1, 3, 5, 7, 9}},
+		{name: "inclusive", lower: Included(3), upper: Included(7), want: []int{3, 5, 7}},
+		{name: "exclusive", lower: Excluded(3), upper: Excluded(7), want: []int{5}},
+		{name: "half-open", lower: Included(3), upper: Excluded(7), want: []int{3, 5}},
+		{name: "between keys", lower: Included(2), upper: Included(8), want: []int{3, 5, 7}},
+		{name: "open lower", lower: Unbound[int](), upper: Excluded(5), want: []int{1, 3}},
+		{name: "open upper", lower: Excluded(5), upper: Unbound[int](), want: []int{7, 9}},
+		{name: "empty", lower: Excluded(3), upper: Excluded(5), want: nil},
+		{name: "inverted", lower: Included(7), upper: Included(3), want: nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			forward := collectRange(m.Range(tc.lower, tc.upper), false)
+			if !slices.Equal(forward, tc.want) {
+				t.Fatalf("forward: expected %v, got %v", tc.want, forward)
+			}
+
+			backward := collectRange(m.Range(tc.lower, tc.upper), true)
+			want := slices.Clone(tc.want)
+			slices.Reverse(want)
+			if !slices.Equal(backward, want) {
+				t.Fatalf("backward: expected %v, got %v", want, backward)
+			}
+		})
+	}
+}
+
+func TestRangeStopsAtBoundsWhenChangingDirection(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	for i := range 10 {
+		m.Put(i, i*10)
+	}
+
+	r := m.Range(Included(3), Excluded(6))
+	if !r.Last() || r.Key() != 5 || r.Value() != 50 {
+		t.Fatalf("expected Last to land on key 5")
+	}
+	if r.Next() {
+		t.Fatalf("expected Next past the upper bound to report false")
+	}
+
+	if !r.First() || r.Key() != 3 {
+		t.Fatalf("expected First to land on key 3")
+	}
+	if r.Prev() {
+		t.Fatalf("expected Prev past the lower bound to report false")
+	}
+	if r.Valid() {
+		t.Fatalf("expected iterator to be invalid after leaving the range")
+	}
+}
+
+func TestRangeSkipsLogicallyDeletedNodes(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	for i := 1; i <= 5; i++ {
+		m.Put(i, i)
+	}
+
+	for _, key := range []int{2, 4} {
+		_, succs, found := m.find(key)
+		if !found {
+			t.Fatalf("expected to locate key %d for deletion simulation", key)
+		}
+		succs[0].val.Store(nil)
+		m.metrics.AddLen(-1)
+	}
+
+	got := collectRange(m.Range(Included(2), Included(4)), false)
+	if !slices.Equal(got, []int{3}) {
+		t.Fatalf("expected range to skip deleted keys, got %v", got)
+	}
+}
@@ -0,0 +1,67 @@
+package skiplist
+
+import "iter"
+
+// All returns an iterator over every key-value pair in ascending key order,
+// for use with range-over-func loops. Like Iterator, it observes concurrent
+// updates without taking a snapshot.
+func (m *SkipListMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it := m.Iterator()
+		for it.Next() {
+			if !yield(it.key, it.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over every key-value pair in descending key
+// order. Each step re-runs the search for the predecessor.
+func (m *SkipListMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it := m.Iterator()
+		for it.Prev() {
+			if !yield(it.key, it.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over every key in ascending order.
+func (m *SkipListMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		it := m.Iterator()
+		for it.Next() {
+			if !yield(it.key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over every value in ascending key order.
+func (m *SkipListMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		it := m.Iterator()
+		for it.Next() {
+			if !yield(it.value) {
+				return
+			}
+		}
+	}
+}
+
+// RangeFrom returns an iterator over the key-value pairs whose keys are
+// greater than or equal to key, in ascending order.
+func (m *SkipListMap[K, V]) RangeFrom(key K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it := m.Iterator()
+		for ok := it.SeekGE(key); ok; ok = it.Next() {
+			if !yield(it.key, it.value) {
+				return
+			}
+		}
+	}
+}
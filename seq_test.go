@@ -0,0 +1,80 @@
+package skiplist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSeqIterators(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	for _, key := range []int{3, 1, 4, 5, 2} {
+		m.Put(key, key*10)
+	}
+
+	var keys []int
+	for k, v := range m.All() {
+		if v != k*10 {
+			t.Fatalf("expected value %d for key %d, got %d", k*10, k, v)
+		}
+		keys = append(keys, k)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !slices.Equal(keys, want) {
+		t.Fatalf("All: expected %v, got %v", want, keys)
+	}
+
+	keys = keys[:0]
+	for k := range m.Backward() {
+		keys = append(keys, k)
+	}
+	if want := []int{5, 4, 3, 2, 1}; !slices.Equal(keys, want) {
+		t.Fatalf("Backward: expected %v, got %v", want, keys)
+	}
+
+	if got, want := slices.Collect(m.Keys()), []int{1, 2, 3, 4, 5}; !slices.Equal(got, want) {
+		t.Fatalf("Keys: expected %v, got %v", want, got)
+	}
+	if got, want := slices.Collect(m.Values()), []int{10, 20, 30, 40, 50}; !slices.Equal(got, want) {
+		t.Fatalf("Values: expected %v, got %v", want, got)
+	}
+
+	keys = keys[:0]
+	for k := range m.RangeFrom(3) {
+		keys = append(keys, k)
+	}
+	if want := []int{3, 4, 5}; !slices.Equal(keys, want) {
+		t.Fatalf("RangeFrom: expected %v, got %v", want, keys)
+	}
+}
+
+func TestSeqIteratorsStopOnBreak(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	for i := range 10 {
+		m.Put(i, i)
+	}
+
+	var keys []int
+	for k := range m.All() {
+		if k == 3 {
+			break
+		}
+		keys = append(keys, k)
+	}
+	if want := []int{0, 1, 2}; !slices.Equal(keys, want) {
+		t.Fatalf("expected %v before break, got %v", want, keys)
+	}
+
+	keys = keys[:0]
+	for k := range m.Backward() {
+		if k == 6 {
+			break
+		}
+		keys = append(keys, k)
+	}
+	if want := []int{9, 8, 7}; !slices.Equal(keys, want) {
+		t.Fatalf("expected %v before break, got %v", want, keys)
+	}
+}
@@ -1,34 +1,127 @@
 package skiplist
 
+import (
+	"cmp"
+	"sync/atomic"
+)
+
 // Less is a function that returns true if a is less than b.
 type Less[K comparable] func(a, b K) bool
 
+// Cmp is a three-way comparison in the style of cmp.Compare: it returns a
+// negative number when a < b, zero when a == b, and a positive number when
+// a > b.
+type Cmp[K any] func(a, b K) int
+
+// Op tells Compute what to do with an entry after the callback returns.
+type Op int
+
+const (
+	// OpKeep leaves the entry as it is; an absent key stays absent.
+	OpKeep Op = iota
+	// OpUpdate stores the returned value, inserting the key if absent.
+	OpUpdate
+	// OpDelete removes the entry if present.
+	OpDelete
+)
+
 // SkipListMap ties components together and keeps public API unchanged.
-type SkipListMap[K comparable, V any] struct {
-	less    Less[K]
+type SkipListMap[K, V any] struct {
+	// compare orders keys and decides their equality.
+	compare Cmp[K]
 	head    *node[K, V]
 	tail    *node[K, V]
+	// toTail is the link the head starts with on every level.
+	toTail  *link[K, V]
 	metrics *Metrics
 	rng     *RNG
+	// maxLevel is the height of the head tower and the cap for new nodes.
+	maxLevel int
+	// height is the tallest tower linked so far; it never shrinks.
+	height atomic.Int32
+	// inline selects inline value storage; see value.go.
+	inline bool
+	// cloneKey, if set, copies a key before a new node keeps it.
+	cloneKey func(K) K
+	// tracer, if set, receives internal events; see tracer.go.
+	tracer Tracer
 	// hot-path function fields (concrete functions, not interfaces)
 	find        func(key K) (preds, succs []*node[K, V], found bool)
-	loadNextPtr func(n *node[K, V], level int) **node[K, V]
+	loadNextPtr func(n *node[K, V], level int) *link[K, V]
 	advanceFrom func(start *node[K, V]) *node[K, V]
 	// mutator groups structural updates; concrete type to avoid interface overhead
 	mutator *mutatorImpl[K, V]
 }
 
-// New returns a new SkipListMap.
+// New returns a new SkipListMap with the default configuration.
 func New[K comparable, V any](less Less[K]) *SkipListMap[K, V] {
-	head, tail := newSentinels[K, V]()
-	rng := newRNG()
+	return NewWithOptions[K, V](less)
+}
+
+// NewWithOptions returns a new SkipListMap configured by opts. It panics if
+// the maximum level is outside [1, MaxLevel], the promotion probability is
+// not in (0, 1), or inline values are requested for a type that cannot be
+// stored inline.
+func NewWithOptions[K comparable, V any](less Less[K], opts ...func(*Config)) *SkipListMap[K, V] {
+	return NewWithCompare[K, V](func(a, b K) int {
+		if less(a, b) {
+			return -1
+		}
+		if a == b {
+			return 0
+		}
+		return 1
+	}, opts...)
+}
+
+// NewOrdered returns a new SkipListMap for a built-in ordered key type,
+// compared with cmp.Compare.
+func NewOrdered[K cmp.Ordered, V any](opts ...func(*Config)) *SkipListMap[K, V] {
+	return NewWithCompare[K, V](cmp.Compare[K], opts...)
+}
+
+// NewWithCompare is NewWithOptions for a three-way comparison. compare also
+// decides key equality, so a search calls it once per node it visits, and K
+// need not be comparable. It panics under the same conditions as
+// NewWithOptions.
+func NewWithCompare[K, V any](compare Cmp[K], opts ...func(*Config)) *SkipListMap[K, V] {
+	cfg := NewConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.maxLevel < 1 || cfg.maxLevel > MaxLevel {
+		panic("skiplist: max level must be in [1, MaxLevel]")
+	}
+	if cfg.p <= 0 || cfg.p >= 1 {
+		panic("skiplist: promotion probability must be in (0, 1)")
+	}
+	if cfg.inline && !fitsInline[V]() {
+		panic("skiplist: inline values need a pointer-free type of at most 16 bytes")
+	}
+
+	head, tail, toTail := newSentinels[K, V](cfg.maxLevel, cfg.inline)
+	var rng *RNG
+	if cfg.seeded {
+		rng = newRNGWithSeed(cfg.seed)
+	} else {
+		rng = newRNG()
+	}
+	rng.maxLevel = cfg.maxLevel
+	rng.p = cfg.p
+
 	m := &SkipListMap[K, V]{
-		less: less,
-		head: head,
-		tail: tail,
-		rng:  rng,
+		compare:  compare,
+		head:     head,
+		tail:     tail,
+		toTail:   toTail,
+		rng:      rng,
+		maxLevel: cfg.maxLevel,
+		inline:   cfg.inline,
+		tracer:   cfg.tracer,
 	}
+	m.height.Store(1)
 	m.metrics = newMetrics(rng)
+	m.metrics.disabled = !cfg.metrics
 	// wire function fields to implementation functions
 	m.find = m.findImpl
 	m.loadNextPtr = m.loadNextPtrImpl
@@ -40,25 +133,18 @@ func New[K comparable, V any](less Less[K]) *SkipListMap[K, V] {
 // Get returns the value for a key.
 // The boolean is true if the key exists, false otherwise.
 func (m *SkipListMap[K, V]) Get(key K) (V, bool) {
-	_, succs, found := m.find(key)
+	_, succ, found := m.search(key)
 	if !found {
 		var v V
 		return v, false
 	}
-	valPtr := succs[0].val.Load()
-	if getAfterFindHook != nil && getAfterFindHook(succs[0]) {
-		valPtr = succs[0].val.Load()
-	}
-	if valPtr == nil {
-		var v V
-		return v, false
-	}
-	return *valPtr, true
+	v, _, ok := m.loadValue(succ)
+	return v, ok
 }
 
 // Contains returns true if the key exists in the skip list.
 func (m *SkipListMap[K, V]) Contains(key K) bool {
-	_, _, found := m.find(key)
+	_, _, found := m.search(key)
 	return found
 }
 
@@ -68,6 +154,14 @@ func (m *SkipListMap[K, V]) Put(key K, value V) (V, bool) {
 	return m.mutator.put(key, value)
 }
 
+// PutIfAbsent inserts the value only if the key is not already present.
+// It returns the existing value and true if the key was present, otherwise
+// the inserted value and false. An insert linearizes at the same level-0 CAS
+// as Put.
+func (m *SkipListMap[K, V]) PutIfAbsent(key K, value V) (actual V, loaded bool) {
+	return m.mutator.putIfAbsent(key, value)
+}
+
 // Delete removes the value associated with the given key from the skip list.
 // The removal is performed in two phases: logical deletion followed by
 // physical unlinking of the node from each level.
@@ -75,6 +169,87 @@ func (m *SkipListMap[K, V]) Delete(key K) (V, bool) {
 	return m.mutator.delete(key)
 }
 
+// DeleteRange removes every key k with lo <= k < hi and returns the number of
+// entries removed. Each key's removal is individually linearizable; the range
+// as a whole does not disappear atomically.
+func (m *SkipListMap[K, V]) DeleteRange(lo, hi K) int {
+	return m.mutator.deleteRange(lo, hi)
+}
+
+// CompareAndSwapFunc swaps the value for key to newValue if the current value
+// equals old according to eq. It reports whether the swap happened; an absent
+// key never matches.
+func (m *SkipListMap[K, V]) CompareAndSwapFunc(key K, old, newValue V, eq func(a, b V) bool) (swapped bool) {
+	return m.mutator.compareAndSwap(key, old, newValue, eq)
+}
+
+// CompareAndDeleteFunc removes the entry for key if its current value equals
+// old according to eq. It reports whether the entry was removed.
+func (m *SkipListMap[K, V]) CompareAndDeleteFunc(key K, old V, eq func(a, b V) bool) (deleted bool) {
+	return m.mutator.compareAndDelete(key, old, eq)
+}
+
+// CompareAndSwap is CompareAndSwapFunc for comparable values, using ==.
+func CompareAndSwap[K any, V comparable](m *SkipListMap[K, V], key K, old, newValue V) (swapped bool) {
+	return m.CompareAndSwapFunc(key, old, newValue, equal[V])
+}
+
+// CompareAndDelete is CompareAndDeleteFunc for comparable values, using ==.
+func CompareAndDelete[K any, V comparable](m *SkipListMap[K, V], key K, old V) (deleted bool) {
+	return m.CompareAndDeleteFunc(key, old, equal[V])
+}
+
+func equal[V comparable](a, b V) bool {
+	return a == b
+}
+
+// Compute atomically updates the entry for key. fn receives the current value
+// and whether the key exists, and returns a new value plus an Op that keeps,
+// updates or deletes the entry. When another writer changes the entry first,
+// fn is called again with the fresh value, so it should be free of side
+// effects. Compute returns the resulting value and whether the key is present
+// afterwards.
+func (m *SkipListMap[K, V]) Compute(key K, fn func(old V, exists bool) (newValue V, op Op)) (actual V, ok bool) {
+	return m.mutator.compute(key, fn)
+}
+
+// First returns the smallest key and its value. The boolean is false if the
+// skip list is empty.
+func (m *SkipListMap[K, V]) First() (K, V, bool) {
+	it := m.Iterator()
+	if !it.Next() {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return it.key, it.value, true
+}
+
+// Last returns the largest key and its value. The boolean is false if the
+// skip list is empty.
+func (m *SkipListMap[K, V]) Last() (K, V, bool) {
+	it := m.Iterator()
+	if !it.Last() {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return it.key, it.value, true
+}
+
+// PopMin removes and returns the smallest entry. Each entry is claimed by
+// exactly one caller, so many goroutines can drain the head concurrently.
+// The boolean is false if the skip list is empty.
+func (m *SkipListMap[K, V]) PopMin() (K, V, bool) {
+	return m.mutator.popMin()
+}
+
+// PopMax removes and returns the largest entry. Each entry is claimed by
+// exactly one caller. The boolean is false if the skip list is empty.
+func (m *SkipListMap[K, V]) PopMax() (K, V, bool) {
+	return m.mutator.popMax()
+}
+
 // SeekGE returns an iterator positioned at the first element whose key is
 // greater than or equal to the provided key. The returned iterator is valid
 // if and only if such an element exists.
@@ -84,6 +259,24 @@ func (m *SkipListMap[K, V]) SeekGE(key K) *Iterator[K, V] {
 	return it
 }
 
+// SeekLT returns an iterator positioned at the last element whose key is
+// strictly less than the provided key. The returned iterator is valid if and
+// only if such an element exists.
+func (m *SkipListMap[K, V]) SeekLT(key K) *Iterator[K, V] {
+	it := m.Iterator()
+	it.SeekLT(key)
+	return it
+}
+
+// SeekLE returns an iterator positioned at the last element whose key is
+// less than or equal to the provided key. The returned iterator is valid if
+// and only if such an element exists.
+func (m *SkipListMap[K, V]) SeekLE(key K) *Iterator[K, V] {
+	it := m.Iterator()
+	it.SeekLE(key)
+	return it
+}
+
 // LenInt64 returns the current length of the skip list as an int64.
 func (m *SkipListMap[K, V]) LenInt64() int64 {
 	return m.metrics.Len()
@@ -91,7 +284,14 @@ func (m *SkipListMap[K, V]) LenInt64() int64 {
 
 // InsertCASStats reports the total number of CAS retries and successful
 // insertions observed at the skip list's bottom level. These counters enable
-// contention analysis in benchmarks.
+// contention analysis in benchmarks. Both are zero when the map was built
+// with WithMetrics(false).
 func (m *SkipListMap[K, V]) InsertCASStats() (retries, successes int64) {
 	return m.metrics.InsertCASStats()
 }
+
+// Stats returns a snapshot of the map's contention and search counters. Only
+// Len is kept when the map was built with WithMetrics(false).
+func (m *SkipListMap[K, V]) Stats() Stats {
+	return m.metrics.Stats()
+}
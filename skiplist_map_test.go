@@ -2,26 +2,22 @@ package skiplist
 
 import (
 	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 )
 
 func TestGetHandlesLogicalDeletionBetweenFindAndLoad(t *testing.T) {
-	m := New[int, int](func(a, b int) bool { return a < b })
-
 	value := 42
-	node := newNode(1, &value, 1)
-	node.next[0].Store(&m.tail)
-	successor := node
-	m.head.next[0].Store(&successor)
-	m.metrics.AddLen(1)
+	var node *node[int, int]
+	tr := &funcTracer{searchEnd: func(any, bool) { node.val.Store(nil) }}
+	m := NewWithOptions[int, int](func(a, b int) bool { return a < b }, WithTracer(tr))
 
-	getAfterFindHook = func(any) bool {
-		node.val.Store(nil)
-		return true
-	}
-	defer func() { getAfterFindHook = nil }()
+	node = newNode(1, &value, 1)
+	node.next[0].Store(m.toTail)
+	m.head.next[0].Store(linkTo(node))
+	m.metrics.AddLen(1)
 
 	got, ok := m.Get(1)
 	if ok {
@@ -41,9 +37,9 @@ func TestFindSkipsLogicallyDeletedNodes(t *testing.T) {
 	v2 := 2
 	n2 := newNode(2, &v2, 1)
 
-	n1.next[0].Store(&n2)
-	n2.next[0].Store(&m.tail)
-	m.head.next[0].Store(&n1)
+	n1.next[0].Store(linkTo(n2))
+	n2.next[0].Store(m.toTail)
+	m.head.next[0].Store(linkTo(n1))
 
 	// Logically delete the first node.
 	n1.val.Store(nil)
@@ -58,8 +54,8 @@ func TestFindSkipsLogicallyDeletedNodes(t *testing.T) {
 	if succs[0] != n2 {
 		t.Fatalf("expected successor to be the live node, got %v", succs[0])
 	}
-	if gotPtr := m.head.next[0].Load(); gotPtr == nil || *gotPtr != n2 {
-		t.Fatalf("expected head to point to live successor, got %v", gotPtr)
+	if got := m.head.next[0].Load().node; got != n2 {
+		t.Fatalf("expected head to point to live successor, got %v", got)
 	}
 
 	if m.Contains(1) {
@@ -70,30 +66,29 @@ func TestFindSkipsLogicallyDeletedNodes(t *testing.T) {
 	}
 }
 
-func TestFindHelpsUnlinkMarkersDuringConcurrentDeletion(t *testing.T) {
+func TestFindHelpsUnlinkMarkedNodesDuringConcurrentDeletion(t *testing.T) {
 	less := func(a, b int) bool { return a < b }
-	m := New[int, int](less)
+	markReady := make(chan struct{})
+	resumeDelete := make(chan struct{})
+	var markOnce sync.Once
+	tr := &funcTracer{mark: func(any) {
+		markOnce.Do(func() {
+			close(markReady)
+			<-resumeDelete
+		})
+	}}
+	m := NewWithOptions[int, int](less, WithTracer(tr))
 
 	v1 := 1
 	target := newNode(1, &v1, 1)
 	v2 := 2
 	successor := newNode(2, &v2, 1)
 
-	target.next[0].Store(&successor)
-	successor.next[0].Store(&m.tail)
-	m.head.next[0].Store(&target)
+	target.next[0].Store(linkTo(successor))
+	successor.next[0].Store(m.toTail)
+	m.head.next[0].Store(linkTo(target))
 	m.metrics.AddLen(2)
 
-	markerReady := make(chan struct{})
-	resumeDelete := make(chan struct{})
-	var markerOnce sync.Once
-
-	ensureMarkerHook = func(any) {
-		markerOnce.Do(func() { close(markerReady) })
-		<-resumeDelete
-	}
-	defer func() { ensureMarkerHook = nil }()
-
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -101,7 +96,7 @@ func TestFindHelpsUnlinkMarkersDuringConcurrentDeletion(t *testing.T) {
 		_, _ = m.Delete(1)
 	}()
 
-	<-markerReady
+	<-markReady
 
 	done := make(chan struct{})
 	go func() {
@@ -112,16 +107,9 @@ func TestFindHelpsUnlinkMarkersDuringConcurrentDeletion(t *testing.T) {
 	}()
 	<-done
 
-	headNextPtr := m.head.next[0].Load()
-	if headNextPtr == nil {
-		t.Fatalf("expected head to reference a successor node")
-	}
-	headNext := *headNextPtr
-	if headNext == nil {
-		t.Fatalf("expected head to reference a concrete node")
-	}
-	if headNext.marker {
-		t.Fatalf("expected head to skip marker node, still observed marker")
+	headNext := m.head.next[0].Load().node
+	if headNext == target {
+		t.Fatalf("expected head to skip the marked node, still observed it")
 	}
 	if headNext.key != 2 {
 		t.Fatalf("expected head to point to successor key 2, got %v", headNext.key)
@@ -190,30 +178,52 @@ func TestPutUpdatesExistingKey(t *testing.T) {
 	}
 }
 
+func TestDeleteMarksLevelZeroLink(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	var marked []any
+	tr := &funcTracer{mark: func(key any) { marked = append(marked, key) }}
+	m := NewWithOptions[int, int](less, WithTracer(tr))
+	m.Put(1, 1)
+	m.Put(2, 2)
+
+	_, n, _ := m.search(1)
+	if _, ok := m.Delete(1); !ok {
+		t.Fatalf("expected Delete to remove key 1")
+	}
+	if !slices.Equal(marked, []any{1}) {
+		t.Fatalf("expected only key 1 to be marked, got %v", marked)
+	}
+	frozen := n.next[0].Load()
+	if frozen == nil || !frozen.marked() {
+		t.Fatalf("expected deleted node to hold a marked link")
+	}
+	if frozen.node.key != 2 || frozen.unmarked.node != frozen.node {
+		t.Fatalf("expected marked link to keep successor 2, got %d", frozen.node.key)
+	}
+	if next := m.head.next[0].Load(); next.node.key != 2 || next.marked() {
+		t.Fatalf("expected head to link the successor through an unmarked link")
+	}
+}
+
 func TestPutRestartDoesNotReportReplacement(t *testing.T) {
 	less := func(a, b int) bool { return a < b }
-	m := New[int, int](less)
+	var m *SkipListMap[int, int]
 
 	var once sync.Once
 	var triggered atomic.Bool
-	putLevelCASHook = func(level int, pred any, expected any, _ any) {
-		if level != 1 {
+	tr := &funcTracer{casAttempt: func(kind CASKind, _ any, level int) {
+		if kind != CASInsert || level != 1 {
 			return
 		}
 		once.Do(func() {
 			triggered.Store(true)
-			predNode, ok := pred.(*node[int, int])
-			if !ok || predNode == nil {
-				return
-			}
-			expectedPtr, ok := expected.(**node[int, int])
-			if !ok || expectedPtr == nil {
-				return
-			}
-			predNode.next[level].CompareAndSwap(expectedPtr, nil)
+			// Key 1 is the only key, so its predecessor is the head. A copy
+			// of the same link still fails the insert's CAS.
+			l := m.head.next[1].Load()
+			m.head.next[1].Store(&link[int, int]{node: l.node})
 		})
-	}
-	defer func() { putLevelCASHook = nil }()
+	}}
+	m = NewWithOptions[int, int](less, WithTracer(tr))
 
 	for range 1000 {
 		old, replaced := m.Put(1, 42)
@@ -228,10 +238,10 @@ func TestPutRestartDoesNotReportReplacement(t *testing.T) {
 		}
 
 		if replaced {
-			t.Fatalf("expected initial insert to report replacement=false before hook triggers")
+			t.Fatalf("expected initial insert to report replacement=false before tracer triggers")
 		}
 		if old != 0 {
-			t.Fatalf("expected zero value before hook triggers, got %d", old)
+			t.Fatalf("expected zero value before tracer triggers, got %d", old)
 		}
 		if _, ok := m.Delete(1); !ok {
 			t.Fatalf("expected Delete to remove key before retrying insert")
@@ -239,7 +249,7 @@ func TestPutRestartDoesNotReportReplacement(t *testing.T) {
 	}
 
 	if !triggered.Load() {
-		t.Fatalf("expected CAS hook to trigger at level 1")
+		t.Fatalf("expected tracer to trigger at level 1")
 	}
 
 	got, ok := m.Get(1)
@@ -452,12 +462,8 @@ func TestSkipListMapSeekGE(t *testing.T) {
 func collectIntKeys(m *SkipListMap[int, int]) []int {
 	keys := make([]int, 0)
 	for node := m.head; ; {
-		nextPtr := node.next[0].Load()
-		if nextPtr == nil {
-			break
-		}
-		next := *nextPtr
-		if next == nil || next == m.tail {
+		next := node.next[0].Load().node
+		if next == m.tail {
 			break
 		}
 		if next.val.Load() != nil {
@@ -468,85 +474,517 @@ func collectIntKeys(m *SkipListMap[int, int]) []int {
 	return keys
 }
 
+func linkTo[K, V any](n *node[K, V]) *link[K, V] {
+	return &link[K, V]{node: n}
+}
+
 func TestLoadNextPtr(t *testing.T) {
 	less := func(a, b int) bool { return a < b }
 	m := New[int, int](less)
 
 	// Test case 1: n == nil
 	result := m.loadNextPtr(nil, 0)
-	if result != &m.tail {
-		t.Errorf("expected &m.tail for nil node, got %p", result)
+	if result != m.toTail {
+		t.Errorf("expected m.toTail for nil node, got %p", result)
 	}
 
 	// Test case 2: level >= len(n.next)
 	v := 1
 	n := newNode(1, &v, 1)       // height 1, so next has 1 element (index 0)
 	result = m.loadNextPtr(n, 1) // level 1 >= 1
-	if result != &m.tail {
-		t.Errorf("expected &m.tail for level out of bounds, got %p", result)
+	if result != m.toTail {
+		t.Errorf("expected m.toTail for level out of bounds, got %p", result)
 	}
 
-	// Test case 3: succ == nil
+	// Test case 3: no link stored
 	v2 := 2
 	n2 := newNode(2, &v2, 2)
-	n2.next[0].Store(nil) // no successor
 	result = m.loadNextPtr(n2, 0)
-	if result != &m.tail {
-		t.Errorf("expected &m.tail for nil successor, got %p", result)
+	if result != m.toTail {
+		t.Errorf("expected m.toTail for missing link, got %p", result)
 	}
 
-	// Test case 4: next == nil
-	v3 := 3
-	n3 := newNode(3, &v3, 1)
-	var nilPtr *node[int, int] = nil
-	n3.next[0].Store(&nilPtr)
-	result = m.loadNextPtr(n3, 0)
-	if result != &nilPtr {
-		t.Errorf("expected nilPtr for nil next, got %p", result)
-	}
-
-	// Test case 5: next not marker
+	// Test case 4: unmarked link
 	v4 := 4
 	n4 := newNode(4, &v4, 1)
 	v5 := 5
 	n5 := newNode(5, &v5, 1)
-	n4.next[0].Store(&n5)
+	toN5 := linkTo(n5)
+	n4.next[0].Store(toN5)
 	result = m.loadNextPtr(n4, 0)
-	if result != &n5 {
-		t.Errorf("expected &n5 for non-marker next, got %p", result)
+	if result != toN5 {
+		t.Errorf("expected link to n5 for live node, got %p", result)
 	}
 
-	// Test case 6: next is marker, markerSucc != nil
+	// Test case 5: marked link yields the link it replaced
 	v6 := 6
 	n6 := newNode(6, &v6, 1)
-	marker := &node[int, int]{key: 6, marker: true, next: make([]atomic.Pointer[*node[int, int]], 1)}
-	marker.next[0].Store(&n6)
+	toN6 := linkTo(n6)
 	v7 := 7
 	n7 := newNode(7, &v7, 1)
-	n7.next[0].Store(&marker)
+	n7.val.Store(nil)
+	n7.next[0].Store(&link[int, int]{node: n6, unmarked: toN6})
 	result = m.loadNextPtr(n7, 0)
-	if result != &n6 {
-		t.Errorf("expected &n6 for marker with successor, got %p", result)
+	if result != toN6 {
+		t.Errorf("expected frozen link to n6 for marked node, got %p", result)
 	}
 
-	// Test case 7: next is marker, markerSucc == nil
-	marker2 := &node[int, int]{key: 8, marker: true, next: make([]atomic.Pointer[*node[int, int]], 1)}
-	marker2.next[0].Store(nil)
+	// Test case 6: deleted but unmarked node gets marked on the way
 	v8 := 8
 	n8 := newNode(8, &v8, 1)
-	n8.next[0].Store(&marker2)
+	toN6 = linkTo(n6)
+	n8.next[0].Store(toN6)
+	n8.val.Store(nil)
 	result = m.loadNextPtr(n8, 0)
-	if result != &m.tail {
-		t.Errorf("expected &m.tail for marker with nil successor, got %p", result)
+	if result != toN6 {
+		t.Errorf("expected frozen link to n6 after helping mark, got %p", result)
+	}
+	if l := n8.next[0].Load(); !l.marked() || l.node != n6 {
+		t.Errorf("expected deleted node to be marked with successor n6")
 	}
 
-	// Test case 8: marker level out of bounds
-	marker3 := &node[int, int]{key: 9, marker: true, next: make([]atomic.Pointer[*node[int, int]], 1)} // only level 0
+	// Test case 7: upper levels are never marked
 	v9 := 9
-	n9 := newNode(9, &v9, 2)   // height 2
-	n9.next[1].Store(&marker3) // at level 1
+	n9 := newNode(9, &v9, 2)
+	n9.next[0].Store(toN5)
+	toN5Upper := linkTo(n5)
+	n9.next[1].Store(toN5Upper)
+	n9.val.Store(nil)
 	result = m.loadNextPtr(n9, 1)
-	if result != &m.tail {
-		t.Errorf("expected &m.tail for marker level out of bounds, got %p", result)
+	if result != toN5Upper {
+		t.Errorf("expected upper link unchanged, got %p", result)
+	}
+	if n9.next[0].Load().marked() {
+		t.Errorf("expected level-1 lookup to leave level 0 unmarked")
+	}
+}
+
+func TestPutIfAbsentInsertsOnlyWhenMissing(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, string](less)
+
+	actual, loaded := m.PutIfAbsent(1, "first")
+	if loaded || actual != "first" {
+		t.Fatalf("expected fresh insert to return (first, false), got (%q, %v)", actual, loaded)
+	}
+
+	actual, loaded = m.PutIfAbsent(1, "second")
+	if !loaded || actual != "first" {
+		t.Fatalf("expected existing value (first, true), got (%q, %v)", actual, loaded)
+	}
+
+	if got, _ := m.Get(1); got != "first" {
+		t.Fatalf("expected PutIfAbsent to leave value untouched, got %q", got)
+	}
+	if gotLen := m.LenInt64(); gotLen != 1 {
+		t.Fatalf("expected length 1, got %d", gotLen)
+	}
+}
+
+func TestPutIfAbsentReplacesLogicallyDeletedNode(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	value := 1
+	stale := newNode(1, &value, 1)
+	stale.next[0].Store(m.toTail)
+	m.head.next[0].Store(linkTo(stale))
+	stale.val.Store(nil)
+
+	actual, loaded := m.PutIfAbsent(1, 2)
+	if loaded || actual != 2 {
+		t.Fatalf("expected insert over deleted node to return (2, false), got (%d, %v)", actual, loaded)
+	}
+	if got, ok := m.Get(1); !ok || got != 2 {
+		t.Fatalf("expected Get to return (2, true), got (%d, %v)", got, ok)
+	}
+	if keys := collectIntKeys(m); !slices.Equal(keys, []int{1}) {
+		t.Fatalf("expected a single live key, got %v", keys)
+	}
+}
+
+func TestPutIfAbsentConcurrentSingleWinner(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	const goroutines = 16
+	const rounds = 200
+
+	for round := range rounds {
+		var winners atomic.Int32
+		results := make([]int, goroutines)
+
+		var wg sync.WaitGroup
+		for g := range goroutines {
+			wg.Add(1)
+			go func(v int) {
+				defer wg.Done()
+				actual, loaded := m.PutIfAbsent(round, v)
+				if !loaded {
+					winners.Add(1)
+				}
+				results[v] = actual
+			}(g)
+		}
+		wg.Wait()
+
+		if got := winners.Load(); got != 1 {
+			t.Fatalf("round %d: expected exactly one inserting call, got %d", round, got)
+		}
+		stored, _ := m.Get(round)
+		for g, actual := range results {
+			if actual != stored {
+				t.Fatalf("round %d: goroutine %d saw %d, stored value is %d", round, g, actual, stored)
+			}
+		}
+	}
+
+	if gotLen := m.LenInt64(); gotLen != rounds {
+		t.Fatalf("expected length %d, got %d", rounds, gotLen)
+	}
+}
+
+func TestCompareAndSwap(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, string](less)
+
+	if CompareAndSwap(m, 1, "", "one") {
+		t.Fatalf("expected CompareAndSwap on absent key to fail")
+	}
+	if m.Contains(1) {
+		t.Fatalf("expected failed CompareAndSwap not to insert")
+	}
+
+	m.Put(1, "one")
+	if CompareAndSwap(m, 1, "uno", "two") {
+		t.Fatalf("expected CompareAndSwap with stale old value to fail")
+	}
+	if !CompareAndSwap(m, 1, "one", "two") {
+		t.Fatalf("expected CompareAndSwap with current value to succeed")
+	}
+	if got, _ := m.Get(1); got != "two" {
+		t.Fatalf("expected value 'two' after swap, got %q", got)
+	}
+}
+
+func TestCompareAndDelete(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, string](less)
+
+	m.Put(1, "one")
+	m.Put(2, "two")
+
+	if CompareAndDelete(m, 1, "uno") {
+		t.Fatalf("expected CompareAndDelete with stale value to fail")
+	}
+	if !CompareAndDelete(m, 1, "one") {
+		t.Fatalf("expected CompareAndDelete with current value to succeed")
+	}
+	if CompareAndDelete(m, 1, "one") {
+		t.Fatalf("expected second CompareAndDelete to fail")
+	}
+
+	if m.Contains(1) || !m.Contains(2) {
+		t.Fatalf("expected only key 1 to be removed")
+	}
+	if gotLen := m.LenInt64(); gotLen != 1 {
+		t.Fatalf("expected length 1 after CompareAndDelete, got %d", gotLen)
+	}
+	if next := m.head.next[0].Load().node; next.key != 2 {
+		t.Fatalf("expected key 1 to be unlinked from level 0, head points at %d", next.key)
+	}
+}
+
+func TestCompareAndSwapFuncNonComparableValues(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, []int](less)
+
+	m.Put(1, []int{1, 2})
+	if !m.CompareAndSwapFunc(1, []int{1, 2}, []int{3}, slices.Equal[[]int]) {
+		t.Fatalf("expected CompareAndSwapFunc to succeed with equal slice")
+	}
+	if m.CompareAndDeleteFunc(1, []int{1, 2}, slices.Equal[[]int]) {
+		t.Fatalf("expected CompareAndDeleteFunc to fail with stale slice")
+	}
+	if !m.CompareAndDeleteFunc(1, []int{3}, slices.Equal[[]int]) {
+		t.Fatalf("expected CompareAndDeleteFunc to succeed with current slice")
+	}
+	if m.Contains(1) {
+		t.Fatalf("expected key to be removed")
+	}
+}
+
+func TestCompareAndSwapConcurrentIncrements(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+	m.Put(0, 0)
+
+	const goroutines = 8
+	const increments = 1000
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range increments {
+				for {
+					cur, _ := m.Get(0)
+					if CompareAndSwap(m, 0, cur, cur+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, _ := m.Get(0); got != goroutines*increments {
+		t.Fatalf("expected counter %d, got %d", goroutines*increments, got)
+	}
+}
+
+func TestComputeOps(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	actual, ok := m.Compute(1, func(old int, exists bool) (int, Op) {
+		if exists {
+			t.Fatalf("expected key to be absent on first Compute")
+		}
+		return 10, OpKeep
+	})
+	if ok || actual != 0 || m.Contains(1) {
+		t.Fatalf("expected OpKeep on absent key to leave it absent, got (%d, %v)", actual, ok)
+	}
+
+	actual, ok = m.Compute(1, func(old int, exists bool) (int, Op) {
+		return old + 10, OpUpdate
+	})
+	if !ok || actual != 10 {
+		t.Fatalf("expected OpUpdate on absent key to insert 10, got (%d, %v)", actual, ok)
+	}
+
+	actual, ok = m.Compute(1, func(old int, exists bool) (int, Op) {
+		if !exists || old != 10 {
+			t.Fatalf("expected existing value 10, got (%d, %v)", old, exists)
+		}
+		return old * 2, OpUpdate
+	})
+	if !ok || actual != 20 {
+		t.Fatalf("expected OpUpdate to store 20, got (%d, %v)", actual, ok)
+	}
+
+	actual, ok = m.Compute(1, func(old int, exists bool) (int, Op) {
+		return 0, OpKeep
+	})
+	if !ok || actual != 20 {
+		t.Fatalf("expected OpKeep to report current value 20, got (%d, %v)", actual, ok)
+	}
+
+	actual, ok = m.Compute(1, func(old int, exists bool) (int, Op) {
+		return 0, OpDelete
+	})
+	if ok || actual != 0 {
+		t.Fatalf("expected OpDelete to report absence, got (%d, %v)", actual, ok)
+	}
+	if m.Contains(1) {
+		t.Fatalf("expected key to be removed by OpDelete")
+	}
+	if gotLen := m.LenInt64(); gotLen != 0 {
+		t.Fatalf("expected length 0 after OpDelete, got %d", gotLen)
+	}
+}
+
+func TestComputeConcurrentCounters(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	const goroutines = 8
+	const increments = 1000
+	const keys = 4
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range increments {
+				m.Compute(i%keys, func(old int, _ bool) (int, Op) {
+					return old + 1, OpUpdate
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	for k := range keys {
+		if got, _ := m.Get(k); got != goroutines*increments/keys {
+			t.Fatalf("expected counter %d for key %d, got %d", goroutines*increments/keys, k, got)
+		}
+	}
+	if gotLen := m.LenInt64(); gotLen != keys {
+		t.Fatalf("expected length %d, got %d", keys, gotLen)
+	}
+}
+
+func TestDeleteRangeRemovesHalfOpenInterval(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	for i := range 100 {
+		m.Put(i, i)
+	}
+
+	if removed := m.DeleteRange(20, 20); removed != 0 {
+		t.Fatalf("expected empty range to remove nothing, got %d", removed)
+	}
+	if removed := m.DeleteRange(30, 20); removed != 0 {
+		t.Fatalf("expected inverted range to remove nothing, got %d", removed)
+	}
+
+	m.Delete(25)
+	if removed := m.DeleteRange(20, 40); removed != 19 {
+		t.Fatalf("expected DeleteRange(20, 40) to remove 19 entries, got %d", removed)
+	}
+
+	keys := collectIntKeys(m)
+	want := make([]int, 0, 80)
+	for i := range 100 {
+		if i < 20 || i >= 40 {
+			want = append(want, i)
+		}
+	}
+	if !slices.Equal(keys, want) {
+		t.Fatalf("unexpected keys after DeleteRange: %v", keys)
+	}
+	if gotLen := m.LenInt64(); gotLen != int64(len(want)) {
+		t.Fatalf("expected length %d, got %d", len(want), gotLen)
+	}
+
+	// Every level must skip the removed run, not only level 0.
+	for level := range MaxLevel {
+		for x := m.head; ; {
+			next := x.next[level].Load().node
+			if next == m.tail {
+				break
+			}
+			if next.key >= 20 && next.key < 40 {
+				t.Fatalf("level %d still links removed key %d", level, next.key)
+			}
+			x = next
+		}
+	}
+}
+
+func TestDeleteRangeConcurrentInserts(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+
+	const total = 2048
+	for i := 0; i < total; i += 2 {
+		m.Put(i, i)
+	}
+
+	var wg sync.WaitGroup
+	var removed int
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		removed = m.DeleteRange(0, total)
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 1; i < total; i += 2 {
+			m.Put(i, i)
+		}
+	}()
+	wg.Wait()
+
+	remaining := collectIntKeys(m)
+	for _, k := range remaining {
+		if k%2 == 0 {
+			t.Fatalf("even key %d survived DeleteRange", k)
+		}
+	}
+	if removed+len(remaining) != total {
+		t.Fatalf("expected removed (%d) + remaining (%d) to equal %d", removed, len(remaining), total)
+	}
+	if gotLen := m.LenInt64(); gotLen != int64(len(remaining)) {
+		t.Fatalf("expected length %d, got %d", len(remaining), gotLen)
+	}
+}
+
+func TestNewOrderedStringKeys(t *testing.T) {
+	m := NewOrdered[string, int]()
+	for i, k := range []string{"pear", "apple", "fig", "banana"} {
+		m.Put(k, i)
+	}
+	if v, ok := m.Get("fig"); !ok || v != 2 {
+		t.Fatalf("expected fig=2, got %d (ok=%v)", v, ok)
+	}
+
+	var keys []string
+	for k := range m.Keys() {
+		keys = append(keys, k)
+	}
+	if want := []string{"apple", "banana", "fig", "pear"}; !slices.Equal(keys, want) {
+		t.Fatalf("expected keys %v, got %v", want, keys)
+	}
+}
+
+func TestNewWithCompareReverseOrder(t *testing.T) {
+	m := NewWithCompare[int, int](func(a, b int) int { return b - a })
+	for i := 0; i < 10; i++ {
+		m.Put(i, i)
+	}
+
+	if got := collectIntKeys(m); !slices.Equal(got, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}) {
+		t.Fatalf("expected descending keys, got %v", got)
+	}
+	it := m.SeekGE(5)
+	if !it.Valid() || it.Key() != 5 || !it.Next() || it.Key() != 4 {
+		t.Fatal("expected SeekGE(5) to walk 5, 4 under the reversed order")
+	}
+	if removed := m.DeleteRange(7, 2); removed != 5 {
+		t.Fatalf("expected DeleteRange to remove 5 keys, got %d", removed)
+	}
+}
+
+func TestNewWithCompareDecidesEquality(t *testing.T) {
+	// Keys that differ only in case are equal under this comparison.
+	m := NewWithCompare[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	m.Put("Key", 1)
+	if old, replaced := m.Put("KEY", 2); !replaced || old != 1 {
+		t.Fatalf("expected KEY to replace Key, got old=%d replaced=%v", old, replaced)
+	}
+	if v, ok := m.Get("key"); !ok || v != 2 {
+		t.Fatalf("expected key=2, got %d (ok=%v)", v, ok)
+	}
+	if n := m.LenInt64(); n != 1 {
+		t.Fatalf("expected length 1, got %d", n)
+	}
+}
+
+func TestSearchComparesMatchOnce(t *testing.T) {
+	var calls int
+	m := NewWithCompare[int, int](func(a, b int) int {
+		calls++
+		return a - b
+	}, WithMaxLevel(8), WithSeed(6))
+	m.Put(1, 1)
+	// The search meets the key on every level of its tower.
+	if _, n, _ := m.search(1); len(n.next) < 2 {
+		t.Fatalf("expected a tower of at least 2 levels, got %d", len(n.next))
+	}
+
+	calls = 0
+	if _, ok := m.Get(1); !ok {
+		t.Fatal("expected key 1 to be present")
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single comparison, got %d", calls)
 	}
 }
@@ -0,0 +1,79 @@
+package skl
+
+import (
+	"fmt"
+	"iter"
+	"math"
+)
+
+// FromSorted builds a SkipList from seq, which must yield keys in strictly
+// ascending order. It links the nodes bottom-up in a single pass without
+// random draws: with promotion probability p, every round(1/p)-th key reaches
+// level 2, every round(1/p)²-th key reaches level 3, and so on. Later Puts use
+// the usual random levels.
+//
+// It returns ErrUnsorted or ErrDuplicateKey, wrapped with the offending key,
+// if seq is out of order, and ErrUnsupportedType if K cannot be compared.
+func FromSorted[K Comparable, V any](config Config, seq iter.Seq2[K, V]) (*SkipList[K, V], error) {
+	list, err := InitSkipList[K, V](config)
+	if err != nil {
+		return nil, err
+	}
+
+	maxLevel := max(config.skipListMaxLevel, 1)
+	stride := levelStride(config.skipListP)
+	head := list.Head()
+	head.forwards = make([]*SLNode[K, V], max(maxLevel, list.level))
+	last := make([]*SLNode[K, V], maxLevel)
+	for i := range last {
+		last[i] = head
+	}
+
+	var count uint
+	tallest := list.level
+	for k, v := range seq {
+		if prev := last[0]; prev != head {
+			switch Compare(prev.Key, k) {
+			case CmpEqual:
+				return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, k)
+			case CmpGreater:
+				return nil, fmt.Errorf("%w: %v after %v", ErrUnsorted, k, prev.Key)
+			}
+		}
+
+		count++
+		height := uint(1)
+		for i := count; height < maxLevel && stride > 0 && i%stride == 0; i /= stride {
+			height++
+		}
+		tallest = max(tallest, height)
+
+		n := &SLNode[K, V]{
+			Key:      k,
+			Value:    v,
+			forwards: make([]*SLNode[K, V], height),
+			backward: last[0],
+		}
+		for i := range height {
+			last[i].forwards[i] = n
+			last[i] = n
+		}
+	}
+
+	head.forwards = head.forwards[:tallest]
+	list.level = tallest
+	list.length = count
+	if last[0] != head {
+		list.tail = last[0]
+	}
+	return list, nil
+}
+
+// levelStride returns how many keys of one level FromSorted passes for each
+// key it promotes to the next, or 0 if p never promotes.
+func levelStride(p float64) uint {
+	if p <= 0 {
+		return 0
+	}
+	return uint(min(max(2, math.Round(1/p)), math.MaxUint32))
+}
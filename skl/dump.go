@@ -0,0 +1,226 @@
+package skl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Formatter renders keys and values for WriteDOT and WriteASCII. A nil field
+// falls back to fmt.Sprint; a Value func that returns "" leaves values out.
+type Formatter[K Comparable, V any] struct {
+	Key   func(K) string
+	Value func(V) string
+}
+
+func (f Formatter[K, V]) label(n *SLNode[K, V]) string {
+	k := fmt.Sprint(n.Key)
+	if f.Key != nil {
+		k = f.Key(n.Key)
+	}
+	v := fmt.Sprint(n.Value)
+	if f.Value != nil {
+		v = f.Value(n.Value)
+	}
+	if v == "" {
+		return k
+	}
+	return k + "=" + v
+}
+
+// dump indexes the list's nodes for WriteDOT and WriteASCII: level-0 nodes in
+// order, then nodes found only on upper levels. It returns the index of every
+// node, their labels, and for each level the indexes of the nodes linked on
+// it.
+func (list *SkipList[K, V]) dump(f Formatter[K, V]) (map[*SLNode[K, V]]int, []string, [][]int) {
+	head := list.Head()
+	index := make(map[*SLNode[K, V]]int)
+	var labels []string
+	levels := make([][]int, list.level)
+	for level := range levels {
+		for n := head.forwards[level]; n != nil; n = n.forwards[level] {
+			i, ok := index[n]
+			if !ok {
+				i = len(labels)
+				index[n] = i
+				labels = append(labels, f.label(n))
+			}
+			levels[level] = append(levels[level], i)
+			if level >= len(n.forwards) {
+				break
+			}
+		}
+	}
+	return index, labels, levels
+}
+
+// WriteDOT writes the list to w as a Graphviz digraph, one record per node
+// with a field for each level it is linked on. Backward pointers are drawn as
+// dotted edges, and tail as an edge from a "tail" point. Nodes linked on an
+// upper level but missing from level 0 are drawn with a red outline. f
+// formats the labels.
+func (list *SkipList[K, V]) WriteDOT(w io.Writer, f Formatter[K, V]) error {
+	head := list.Head()
+	index, labels, levels := list.dump(f)
+	heights := make([]int, len(labels))
+	for level, chain := range levels {
+		for _, i := range chain {
+			heights[i] = level + 1
+		}
+	}
+	name := func(n *SLNode[K, V]) string {
+		if n == head {
+			return "head"
+		}
+		if i, ok := index[n]; ok {
+			return fmt.Sprintf("n%d", i)
+		}
+		return ""
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph skiplist {")
+	fmt.Fprintln(bw, "\trankdir=LR;")
+	fmt.Fprintln(bw, "\tnode [shape=record];")
+	fmt.Fprintf(bw, "\thead [label=\"{%s<k> head}\"];\n", dotPorts(len(levels)))
+	onBase := 0
+	if len(levels) > 0 {
+		onBase = len(levels[0])
+	}
+	for i, label := range labels {
+		attrs := ""
+		if i >= onBase {
+			attrs = ", color=red"
+		}
+		fmt.Fprintf(bw, "\tn%d [label=\"{%s<k> %s}\"%s];\n", i, dotPorts(heights[i]), dotEscape(label), attrs)
+	}
+	fmt.Fprintln(bw, "\tnil [shape=point];")
+
+	for level, chain := range levels {
+		from := "head"
+		for _, i := range chain {
+			fmt.Fprintf(bw, "\t%s:l%d -> n%d:l%d;\n", from, level, i, level)
+			from = fmt.Sprintf("n%d", i)
+		}
+		fmt.Fprintf(bw, "\t%s:l%d -> nil;\n", from, level)
+	}
+	if len(levels) > 0 {
+		for n := head.forwards[0]; n != nil; n = n.forwards[0] {
+			if to := name(n.backward); to != "" {
+				fmt.Fprintf(bw, "\tn%d:k -> %s:k [style=dotted, constraint=false];\n", index[n], to)
+			}
+		}
+	}
+	if to := name(list.tail); to != "" {
+		fmt.Fprintln(bw, "\ttail [shape=plaintext];")
+		fmt.Fprintf(bw, "\ttail -> %s:k [style=dotted];\n", to)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// WriteASCII writes the list to w as text, one line per level from the top
+// down with each node in the column of its level-0 position, followed by a
+// line for the backward pointers and one naming the tail:
+//
+//	L1 head ---------> 2=20 ---------> nil
+//	L0 head -> 1=10 -> 2=20 -> 3=30 -> nil
+//	<- head <- 1=10 <- 2=20 <- 3=30
+//	tail: 3=30
+//
+// A backward pointer that does not lead to the node's level-0 predecessor is
+// drawn "<?" and described after the tail. Nodes linked on an upper level but
+// missing from level 0 are listed last. f formats the labels.
+func (list *SkipList[K, V]) WriteASCII(w io.Writer, f Formatter[K, V]) error {
+	head := list.Head()
+	index, labels, levels := list.dump(f)
+	describe := func(n *SLNode[K, V]) string {
+		switch n {
+		case nil:
+			return "nil"
+		case head:
+			return "head"
+		}
+		if i, ok := index[n]; ok {
+			return labels[i]
+		}
+		return fmt.Sprintf("unlinked node %v", n.Key)
+	}
+
+	bw := bufio.NewWriter(w)
+	columns := 0
+	if len(levels) > 0 {
+		columns = len(levels[0])
+	}
+	var strays []string
+	prefix := len(fmt.Sprintf("L%d", len(levels)-1))
+	for level := len(levels) - 1; level >= 0; level-- {
+		var b strings.Builder
+		fmt.Fprintf(&b, "%-*s head ", prefix, fmt.Sprintf("L%d", level))
+		col := 0
+		for _, i := range levels[level] {
+			if i >= columns {
+				strays = append(strays, fmt.Sprintf("L%d: %s", level, labels[i]))
+				continue
+			}
+			for ; col < i; col++ {
+				b.WriteString(strings.Repeat("-", utf8.RuneCountInString(labels[col])+4))
+			}
+			fmt.Fprintf(&b, "-> %s ", labels[i])
+			col++
+		}
+		for ; col < columns; col++ {
+			b.WriteString(strings.Repeat("-", utf8.RuneCountInString(labels[col])+4))
+		}
+		b.WriteString("-> nil")
+		fmt.Fprintln(bw, b.String())
+	}
+
+	var b strings.Builder
+	var wrong []string
+	fmt.Fprintf(&b, "%-*s head", prefix, "<-")
+	prev := head
+	if len(levels) > 0 {
+		for n := head.forwards[0]; n != nil; prev, n = n, n.forwards[0] {
+			arrow := "<-"
+			if n.backward != prev {
+				arrow = "<?"
+				wrong = append(wrong, fmt.Sprintf("backward of %s: %s", labels[index[n]], describe(n.backward)))
+			}
+			fmt.Fprintf(&b, " %s %s", arrow, labels[index[n]])
+		}
+	}
+	fmt.Fprintln(bw, b.String())
+	fmt.Fprintf(bw, "tail: %s\n", describe(list.tail))
+	for _, s := range wrong {
+		fmt.Fprintln(bw, s)
+	}
+	for _, s := range strays {
+		fmt.Fprintf(bw, "not on level 0: %s\n", s)
+	}
+	return bw.Flush()
+}
+
+// dotPorts returns the record fields for a tower of the given height, top
+// level first, each followed by a separator.
+func dotPorts(height int) string {
+	var b strings.Builder
+	for level := height - 1; level >= 0; level-- {
+		fmt.Fprintf(&b, "<l%d> |", level)
+	}
+	return b.String()
+}
+
+// dotEscape escapes the characters that are special in record labels.
+func dotEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`{}|<>"\ `, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package skl
+
+import "iter"
+
+// All returns an iterator over every key-value pair in ascending key order,
+// for use with range-over-func loops.
+func (list *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it := list.newIterator()
+		for it.HasNext() {
+			if _, err := it.Next(); err != nil {
+				return
+			}
+			if !yield(it.curr.Key, it.curr.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over every key-value pair in descending key
+// order, following the backward pointers.
+func (list *SkipList[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it := list.newIterator()
+		last := list.tail
+		if _, err := it.Last(); err != nil {
+			return
+		}
+		if !yield(last.Key, last.Value) {
+			return
+		}
+		for it.HasPrev() {
+			node := it.curr
+			if _, err := it.Prev(); err != nil {
+				return
+			}
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over every key in ascending order.
+func (list *SkipList[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range list.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over every value in ascending key order.
+func (list *SkipList[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range list.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// RangeFrom returns an iterator over the key-value pairs whose keys are
+// greater than or equal to searchKey, in ascending order.
+func (list *SkipList[K, V]) RangeFrom(searchKey K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		node, err := list.FindGreaterOrEqual(searchKey)
+		if err != nil {
+			return
+		}
+		for ; node != nil; node = node.Next() {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
@@ -293,6 +293,10 @@ func (s *slIterator[K, V]) Last() (V, error) {
 
 // Iterator returns a bidirectional iterator over the list's values.
 func (list *SkipList[K, V]) Iterator() Iterator[V] {
+	return list.newIterator()
+}
+
+func (list *SkipList[K, V]) newIterator() *slIterator[K, V] {
 	h := list.Head()
 	return &slIterator[K, V]{
 		head: h,
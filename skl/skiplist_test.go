@@ -6,6 +6,8 @@ import (
 	"math"
 	"math/rand/v2"
 	"reflect"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 )
@@ -1609,3 +1611,404 @@ func TestSkipList_randomLevelMaxLevelOne(t *testing.T) {
 		t.Errorf("expected %v, got %v", uint(1), level)
 	}
 }
+
+func TestSkipList_Seq(t *testing.T) {
+	t.Parallel()
+	cfg := testConfig(t)
+	list, err := InitSkipList[int, int](cfg)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, v := range []int{4, 2, 5, 1, 3} {
+		list.Put(v, v*10)
+	}
+
+	var keys, values []int
+	for k, v := range list.All() {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual([]int{1, 2, 3, 4, 5}, keys) {
+		t.Errorf("expected %v, got %v", []int{1, 2, 3, 4, 5}, keys)
+	}
+	if !reflect.DeepEqual([]int{10, 20, 30, 40, 50}, values) {
+		t.Errorf("expected %v, got %v", []int{10, 20, 30, 40, 50}, values)
+	}
+
+	keys = keys[:0]
+	for k, v := range list.Backward() {
+		if v != k*10 {
+			t.Errorf("expected %v, got %v", k*10, v)
+		}
+		keys = append(keys, k)
+	}
+	if !reflect.DeepEqual([]int{5, 4, 3, 2, 1}, keys) {
+		t.Errorf("expected %v, got %v", []int{5, 4, 3, 2, 1}, keys)
+	}
+
+	if got := slices.Collect(list.Keys()); !reflect.DeepEqual([]int{1, 2, 3, 4, 5}, got) {
+		t.Errorf("expected %v, got %v", []int{1, 2, 3, 4, 5}, got)
+	}
+	if got := slices.Collect(list.Values()); !reflect.DeepEqual([]int{10, 20, 30, 40, 50}, got) {
+		t.Errorf("expected %v, got %v", []int{10, 20, 30, 40, 50}, got)
+	}
+
+	keys = keys[:0]
+	for k := range list.RangeFrom(3) {
+		if k == 5 {
+			break
+		}
+		keys = append(keys, k)
+	}
+	if !reflect.DeepEqual([]int{3, 4}, keys) {
+		t.Errorf("expected %v, got %v", []int{3, 4}, keys)
+	}
+}
+
+func TestSkipList_SeqEmpty(t *testing.T) {
+	t.Parallel()
+	cfg := testConfig(t)
+	list, err := InitSkipList[int, int](cfg)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for k := range list.All() {
+		t.Errorf("unexpected key %v", k)
+	}
+	for k := range list.Backward() {
+		t.Errorf("unexpected key %v", k)
+	}
+	for k := range list.RangeFrom(0) {
+		t.Errorf("unexpected key %v", k)
+	}
+}
+
+func TestSkipList_FromSorted(t *testing.T) {
+	t.Parallel()
+	seq := func(yield func(int, int) bool) {
+		for i := 1; i <= 64; i++ {
+			if !yield(i, i*10) {
+				return
+			}
+		}
+	}
+
+	list, err := FromSorted[int, int](testConfig(t), seq)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list.Len() != 64 {
+		t.Errorf("expected %v, got %v", 64, list.Len())
+	}
+
+	// Every second key reaches level 2, every fourth level 3, and so on.
+	for level, want := range []int{64, 32, 16, 8, 4, 2, 1} {
+		got := 0
+		for n := list.Head().forwards[level]; n != nil; n = n.forwards[level] {
+			got++
+		}
+		if got != want {
+			t.Errorf("level %d: expected %v, got %v", level, want, got)
+		}
+	}
+
+	keys := slices.Collect(list.Keys())
+	if len(keys) != 64 || keys[0] != 1 || keys[63] != 64 {
+		t.Errorf("expected keys 1..64, got %v", keys)
+	}
+	var backward []int
+	for k := range list.Backward() {
+		backward = append(backward, k)
+	}
+	slices.Reverse(backward)
+	if !reflect.DeepEqual(keys, backward) {
+		t.Errorf("expected %v, got %v", keys, backward)
+	}
+
+	list.Put(0, 0)
+	list.Put(100, 1000)
+	if err := list.Remove(32); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if v, err := list.Get(100); err != nil || v != 1000 {
+		t.Errorf("expected %v, got %v (%v)", 1000, v, err)
+	}
+	if list.tail.Key != 100 {
+		t.Errorf("expected %v, got %v", 100, list.tail.Key)
+	}
+	if list.Len() != 65 {
+		t.Errorf("expected %v, got %v", 65, list.Len())
+	}
+}
+
+func TestSkipList_FromSortedRejectsBadInput(t *testing.T) {
+	t.Parallel()
+	cases := map[string]struct {
+		keys []int
+		want error
+	}{
+		"unsorted":  {keys: []int{1, 3, 2}, want: ErrUnsorted},
+		"duplicate": {keys: []int{1, 2, 2}, want: ErrDuplicateKey},
+	}
+	for name, tc := range cases {
+		seq := func(yield func(int, int) bool) {
+			for _, k := range tc.keys {
+				if !yield(k, k) {
+					return
+				}
+			}
+		}
+		if _, err := FromSorted[int, int](testConfig(t), seq); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", name, tc.want, err)
+		}
+	}
+
+	empty, err := FromSorted[int, int](testConfig(t), func(func(int, int) bool) {})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if empty.Len() != 0 || empty.tail != nil {
+		t.Errorf("expected empty list, got length %v", empty.Len())
+	}
+}
+
+func TestSkipList_StructureStats(t *testing.T) {
+	t.Parallel()
+	seq := func(yield func(int, int) bool) {
+		for i := 1; i <= 16; i++ {
+			if !yield(i, i) {
+				return
+			}
+		}
+	}
+	list, err := FromSorted[int, int](testConfig(t), seq)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	st := list.StructureStats()
+	if !reflect.DeepEqual([]int{16, 8, 4, 2, 1}, st.Levels) {
+		t.Errorf("expected %v, got %v", []int{16, 8, 4, 2, 1}, st.Levels)
+	}
+	if !reflect.DeepEqual([]float64{16, 8, 4, 2, 1}, st.Expected) {
+		t.Errorf("expected %v, got %v", []float64{16, 8, 4, 2, 1}, st.Expected)
+	}
+	if !reflect.DeepEqual([]int{8, 4, 2, 1, 1}, st.Heights) {
+		t.Errorf("expected %v, got %v", []int{8, 4, 2, 1, 1}, st.Heights)
+	}
+	if st.TallestTower != 5 || st.P != 0.5 {
+		t.Errorf("expected %v, got %v", []any{5, 0.5}, []any{st.TallestTower, st.P})
+	}
+
+	// Removing the only tower of height 5 lowers the list.
+	if err := list.Remove(16); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	st = list.StructureStats()
+	if !reflect.DeepEqual([]int{15, 7, 3, 1}, st.Levels) {
+		t.Errorf("expected %v, got %v", []int{15, 7, 3, 1}, st.Levels)
+	}
+	if !reflect.DeepEqual([]int{8, 4, 2, 1}, st.Heights) {
+		t.Errorf("expected %v, got %v", []int{8, 4, 2, 1}, st.Heights)
+	}
+}
+
+func TestSkipList_StructureStatsEmpty(t *testing.T) {
+	t.Parallel()
+	list, err := InitSkipList[int, int](testConfig(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	st := list.StructureStats()
+	if !reflect.DeepEqual([]int{0, 0}, st.Levels) {
+		t.Errorf("expected %v, got %v", []int{0, 0}, st.Levels)
+	}
+	if st.TallestTower != 0 || len(st.Heights) != 0 {
+		t.Errorf("expected no towers, got %v", st.Heights)
+	}
+}
+
+func TestSkipList_Validate(t *testing.T) {
+	t.Parallel()
+	list, err := InitSkipList[int, int](testConfig(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := list.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for i := 1; i <= 200; i++ {
+		list.Put(i, i)
+	}
+	for i := 1; i <= 200; i += 3 {
+		if err := list.Remove(i); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := list.Remove(200); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := list.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSkipList_ValidateReportsBrokenInvariants(t *testing.T) {
+	t.Parallel()
+	find := func(list *SkipList[int, int], key int) *SLNode[int, int] {
+		n, err := list.FindGreaterOrEqual(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return n
+	}
+	cases := map[string]struct {
+		corrupt func(list *SkipList[int, int])
+		want    string
+	}{
+		"level 0 order": {
+			corrupt: func(list *SkipList[int, int]) { find(list, 5).Key = 50 },
+			want:    "level 0: key 6 follows 50",
+		},
+		"backward": {
+			corrupt: func(list *SkipList[int, int]) { find(list, 4).backward = find(list, 2) },
+			want:    "key 4 points back to key 2 instead of key 3",
+		},
+		"first backward": {
+			corrupt: func(list *SkipList[int, int]) { find(list, 0).backward = nil },
+			want:    "key 0 points back to nil instead of the head",
+		},
+		"tail": {
+			corrupt: func(list *SkipList[int, int]) { list.tail = find(list, 8) },
+			want:    "tail is key 8, but the last node is key 9",
+		},
+		"length": {
+			corrupt: func(list *SkipList[int, int]) { list.length++ },
+			want:    "level 0 holds 10 keys, Len reports 11",
+		},
+		"not on level 0": {
+			corrupt: func(list *SkipList[int, int]) {
+				stray := &SLNode[int, int]{Key: 100, forwards: make([]*SLNode[int, int], 2)}
+				stray.forwards[1] = list.Head().forwards[1]
+				list.Head().forwards[1] = stray
+			},
+			want: "level 1: key 100 is not linked on level 0",
+		},
+		"upper level order": {
+			corrupt: func(list *SkipList[int, int]) {
+				n1, n3 := find(list, 1), find(list, 3)
+				list.Head().forwards[1] = n3
+				n3.forwards[1] = n1
+				n1.forwards[1] = nil
+			},
+			want: "level 1: key 1 follows 3",
+		},
+		"above height": {
+			corrupt: func(list *SkipList[int, int]) { list.level = 1 },
+			want:    "level 1: key 1 is linked above the height 1",
+		},
+	}
+
+	for name, tc := range cases {
+		// Keys 1, 3, 5, 7 and 9 reach level 1, with 3 and 7 going higher.
+		seq := func(yield func(int, int) bool) {
+			for i := range 10 {
+				if !yield(i, i) {
+					return
+				}
+			}
+		}
+		list, err := FromSorted[int, int](testConfig(t), seq)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := list.Validate(); err != nil {
+			t.Fatalf("%s: unexpected error before corruption: %v", name, err)
+		}
+
+		tc.corrupt(list)
+		err = list.Validate()
+		if !errors.Is(err, ErrCorruptedList) || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, tc.want, err)
+		}
+	}
+}
+
+func TestSkipList_WriteASCII(t *testing.T) {
+	t.Parallel()
+	seq := func(yield func(int, int) bool) {
+		for i := 1; i <= 5; i++ {
+			if !yield(i, i*10) {
+				return
+			}
+		}
+	}
+	list, err := FromSorted[int, int](testConfig(t), seq)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var b strings.Builder
+	if err := list.WriteASCII(&b, Formatter[int, int]{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "" +
+		"L2 head -------------------------> 4=40 ---------> nil\n" +
+		"L1 head ---------> 2=20 ---------> 4=40 ---------> nil\n" +
+		"L0 head -> 1=10 -> 2=20 -> 3=30 -> 4=40 -> 5=50 -> nil\n" +
+		"<- head <- 1=10 <- 2=20 <- 3=30 <- 4=40 <- 5=50\n" +
+		"tail: 5=50\n"
+	if b.String() != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, b.String())
+	}
+
+	// A broken backward pointer is flagged and described.
+	n, err := list.FindGreaterOrEqual(4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n.backward = list.Head()
+	b.Reset()
+	if err := list.WriteASCII(&b, Formatter[int, int]{Value: func(int) string { return "" }}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(b.String(), "<- 3 <? 4 <- 5\n") || !strings.HasSuffix(b.String(), "backward of 4: head\n") {
+		t.Errorf("expected the backward pointer of 4 to be flagged, got\n%s", b.String())
+	}
+}
+
+func TestSkipList_WriteDOT(t *testing.T) {
+	t.Parallel()
+	seq := func(yield func(string, int) bool) {
+		_ = yield("a b", 1) && yield("c|d", 2)
+	}
+	list, err := FromSorted[string, int](testConfig(t), seq)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var b strings.Builder
+	f := Formatter[string, int]{Value: func(v int) string { return fmt.Sprintf("{%d}", v) }}
+	if err := list.WriteDOT(&b, f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := b.String()
+	for _, line := range []string{
+		`n0 [label="{<l0> |<k> a\ b=\{1\}}"];`,
+		`n1 [label="{<l1> |<l0> |<k> c\|d=\{2\}}"];`,
+		"head:l0 -> n0:l0;",
+		"n0:l0 -> n1:l0;",
+		"n1:l0 -> nil;",
+		"head:l1 -> n1:l1;",
+		"n0:k -> head:k [style=dotted, constraint=false];",
+		"n1:k -> n0:k [style=dotted, constraint=false];",
+		"tail -> n1:k [style=dotted];",
+	} {
+		if !strings.Contains(out, "\t"+line+"\n") {
+			t.Errorf("expected line %q in\n%s", line, out)
+		}
+	}
+}
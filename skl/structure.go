@@ -0,0 +1,54 @@
+package skl
+
+import "math"
+
+// StructureStats describes the shape of a SkipList: how many nodes each
+// level holds and how that compares with the promotion probability. Remove
+// unlinks nodes at once, so unlike the lock-free map in the parent package a
+// SkipList never holds deleted or marked nodes.
+type StructureStats struct {
+	// Levels[i] is the number of nodes linked on level i.
+	Levels []int
+	// Expected[i] is the number of nodes level i should hold given the nodes
+	// on level 0 and the configured promotion probability P.
+	Expected []float64
+	// Heights[h-1] is the number of nodes whose tower has height h.
+	Heights []int
+	// TallestTower is the height of the tallest tower.
+	TallestTower int
+	// P is the configured promotion probability.
+	P float64
+}
+
+// StructureStats walks every level of the list and reports its shape. Like
+// the other methods it must not run concurrently with Put or Remove.
+func (list *SkipList[K, V]) StructureStats() StructureStats {
+	head := list.Head()
+	st := StructureStats{
+		Levels:   make([]int, list.level),
+		Expected: make([]float64, list.level),
+		P:        list.config.skipListP,
+	}
+	for i := range st.Levels {
+		for n := head.forwards[i]; n != nil; n = n.forwards[i] {
+			st.Levels[i]++
+		}
+		if st.Levels[i] > 0 {
+			st.TallestTower = i + 1
+		}
+	}
+
+	// Towers are linked on every level below their top, so the number of
+	// nodes of height h is the drop in node count from level h-1 to h.
+	st.Heights = make([]int, st.TallestTower)
+	for h := range st.Heights {
+		st.Heights[h] = st.Levels[h]
+		if h+1 < len(st.Levels) {
+			st.Heights[h] -= st.Levels[h+1]
+		}
+	}
+	for i := range st.Expected {
+		st.Expected[i] = float64(st.Levels[0]) * math.Pow(st.P, float64(i))
+	}
+	return st
+}
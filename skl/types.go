@@ -173,4 +173,12 @@ var (
 	ErrMalformedList = errors.New("the list was not init-ed properly")
 	// ErrKeyNotFound is returned when a key is not found in the SkipList.
 	ErrKeyNotFound = errors.New("key not found")
+	// ErrUnsorted is returned by FromSorted when a key is smaller than the
+	// key before it.
+	ErrUnsorted = errors.New("keys are not in ascending order")
+	// ErrDuplicateKey is returned by FromSorted when a key repeats.
+	ErrDuplicateKey = errors.New("duplicate key")
+	// ErrCorruptedList is returned by Validate, wrapped with a description of
+	// the first broken invariant.
+	ErrCorruptedList = errors.New("list invariants do not hold")
 )
@@ -0,0 +1,81 @@
+package skl
+
+import "fmt"
+
+// Validate checks the list's invariants and reports the first one that
+// fails, naming the keys and level involved:
+//
+//   - keys strictly increase along every level;
+//   - every level above 0 links a subsequence of level 0;
+//   - the list is at most as tall as the configured maximum level, and no
+//     level at or above its current height holds nodes;
+//   - each node's backward pointer leads to its level-0 predecessor, and
+//     tail is the last node;
+//   - the nodes on level 0 number Len.
+//
+// The returned error wraps ErrCorruptedList.
+func (list *SkipList[K, V]) Validate() error {
+	head := list.Head()
+	maxLevel := max(list.config.skipListMaxLevel, list.config.skipListDefaultLevel)
+	if list.level > maxLevel {
+		return fmt.Errorf("%w: height %d exceeds the maximum level %d", ErrCorruptedList, list.level, maxLevel)
+	}
+	if uint(len(head.forwards)) < list.level {
+		return fmt.Errorf("%w: head has %d levels, list height is %d", ErrCorruptedList, len(head.forwards), list.level)
+	}
+	for level := list.level; level < uint(len(head.forwards)); level++ {
+		if n := head.forwards[level]; n != nil {
+			return fmt.Errorf("%w: level %d: key %v is linked above the height %d", ErrCorruptedList, level, n.Key, list.level)
+		}
+	}
+
+	pos := make(map[*SLNode[K, V]]int)
+	prev := head
+	for n := head.forwards[0]; n != nil; prev, n = n, n.forwards[0] {
+		if prev != head && Compare(prev.Key, n.Key) != CmpLess {
+			return fmt.Errorf("%w: level 0: key %v follows %v", ErrCorruptedList, n.Key, prev.Key)
+		}
+		if n.backward != prev {
+			return fmt.Errorf("%w: key %v points back to %s instead of %s", ErrCorruptedList, n.Key, list.describe(n.backward), list.describe(prev))
+		}
+		pos[n] = len(pos)
+	}
+	last := prev
+	if last == head {
+		last = nil
+	}
+	if list.tail != last {
+		return fmt.Errorf("%w: tail is %s, but the last node is %s", ErrCorruptedList, list.describe(list.tail), list.describe(last))
+	}
+	if uint(len(pos)) != list.length {
+		return fmt.Errorf("%w: level 0 holds %d keys, Len reports %d", ErrCorruptedList, len(pos), list.length)
+	}
+
+	for level := uint(1); level < list.level; level++ {
+		prev = head
+		for n := head.forwards[level]; n != nil; prev, n = n, n.forwards[level] {
+			if level >= uint(len(n.forwards)) {
+				return fmt.Errorf("%w: level %d: key %v has %d levels", ErrCorruptedList, level, n.Key, len(n.forwards))
+			}
+			i, ok := pos[n]
+			if !ok {
+				return fmt.Errorf("%w: level %d: key %v is not linked on level 0", ErrCorruptedList, level, n.Key)
+			}
+			if prev != head && i <= pos[prev] {
+				return fmt.Errorf("%w: level %d: key %v follows %v", ErrCorruptedList, level, n.Key, prev.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// describe names n in Validate's errors.
+func (list *SkipList[K, V]) describe(n *SLNode[K, V]) string {
+	switch n {
+	case nil:
+		return "nil"
+	case list.headNote:
+		return "the head"
+	}
+	return fmt.Sprintf("key %v", n.Key)
+}
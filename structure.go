@@ -0,0 +1,67 @@
+package skiplist
+
+import "math"
+
+// StructureStats describes the shape of a map: how many nodes each level
+// holds and how that compares with the promotion probability. Nodes that are
+// deleted but still linked are counted like live ones, since searches still
+// walk over them.
+type StructureStats struct {
+	// Levels[i] is the number of nodes linked on level i.
+	Levels []int
+	// Expected[i] is the number of nodes level i should hold given the nodes
+	// on level 0 and the configured promotion probability P.
+	Expected []float64
+	// Heights[h-1] is the number of nodes on level 0 whose tower has height
+	// h. A tower whose upper levels are still being linked counts at its
+	// full height.
+	Heights []int
+	// TallestTower is the height of the tallest tower on level 0.
+	TallestTower int
+	// Deleted counts logically deleted nodes still linked on level 0.
+	Deleted int
+	// Marked counts nodes on level 0 whose link is marked, the stand-in for
+	// the marker nodes of the original design.
+	Marked int
+	// P is the configured promotion probability.
+	P float64
+}
+
+// StructureStats walks every level of the map and reports its shape. It is
+// safe to call concurrently with writers; it helps no one and each level is
+// walked separately, so under concurrent writes the counts describe no single
+// moment and levels may disagree with each other slightly.
+func (m *SkipListMap[K, V]) StructureStats() StructureStats {
+	top := m.topLevel()
+	st := StructureStats{
+		Levels:   make([]int, top),
+		Expected: make([]float64, top),
+		Heights:  make([]int, m.maxLevel),
+		P:        m.rng.p,
+	}
+
+	for n := m.head.next[0].Load().node; n != m.tail; {
+		l := n.next[0].Load()
+		st.Levels[0]++
+		st.Heights[len(n.next)-1]++
+		st.TallestTower = max(st.TallestTower, len(n.next))
+		if m.deleted(n) {
+			st.Deleted++
+		}
+		if l.marked() {
+			st.Marked++
+		}
+		n = l.node
+	}
+	for i := 1; i < top; i++ {
+		for n := m.head.next[i].Load().node; n != m.tail; n = n.next[i].Load().node {
+			st.Levels[i]++
+		}
+	}
+
+	for i := range st.Expected {
+		st.Expected[i] = float64(st.Levels[0]) * math.Pow(st.P, float64(i))
+	}
+	st.Heights = st.Heights[:st.TallestTower]
+	return st
+}
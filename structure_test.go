@@ -0,0 +1,111 @@
+package skiplist
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestStructureStatsOfPerfectTowers(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	keys := make([]int, 16)
+	for i := range keys {
+		keys[i] = i + 1
+	}
+	m, err := FromSorted(less, sortedInts(keys...))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	st := m.StructureStats()
+	if want := []int{16, 8, 4, 2, 1}; !slices.Equal(st.Levels, want) {
+		t.Fatalf("expected levels %v, got %v", want, st.Levels)
+	}
+	if want := []float64{16, 8, 4, 2, 1}; !slices.Equal(st.Expected, want) {
+		t.Fatalf("expected %v expected nodes, got %v", want, st.Expected)
+	}
+	if want := []int{8, 4, 2, 1, 1}; !slices.Equal(st.Heights, want) {
+		t.Fatalf("expected heights %v, got %v", want, st.Heights)
+	}
+	if st.TallestTower != 5 || st.P != P {
+		t.Fatalf("expected tallest tower 5 and P %v, got %d and %v", P, st.TallestTower, st.P)
+	}
+	if st.Deleted != 0 || st.Marked != 0 {
+		t.Fatalf("expected no deleted or marked nodes, got %d and %d", st.Deleted, st.Marked)
+	}
+}
+
+func TestStructureStatsCountsDeletedAndMarkedNodes(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+	for i := range 4 {
+		m.Put(i, i)
+	}
+
+	// Delete two nodes logically, and mark only one of them, leaving both
+	// linked as a deleter that has not finished would.
+	_, n1, _ := m.search(1)
+	_, n2, _ := m.search(2)
+	for _, n := range []*node[int, int]{n1, n2} {
+		if _, ok := m.mutator.logicalDelete(n); !ok {
+			t.Fatalf("expected logical delete of %d to succeed", n.key)
+		}
+	}
+	m.mark(n2)
+
+	st := m.StructureStats()
+	if st.Levels[0] != 4 || st.Deleted != 2 || st.Marked != 1 {
+		t.Fatalf("expected 4 linked, 2 deleted and 1 marked node, got %d, %d and %d", st.Levels[0], st.Deleted, st.Marked)
+	}
+
+	// A search unlinks both, after which nothing deleted is left.
+	m.Get(3)
+	if st := m.StructureStats(); st.Levels[0] != 2 || st.Deleted != 0 || st.Marked != 0 {
+		t.Fatalf("expected 2 linked nodes and nothing deleted, got %+v", st)
+	}
+}
+
+func TestStructureStatsEmptyMap(t *testing.T) {
+	m := NewOrdered[int, int]()
+	st := m.StructureStats()
+	if !slices.Equal(st.Levels, []int{0}) || st.TallestTower != 0 || len(st.Heights) != 0 {
+		t.Fatalf("expected an empty shape, got %+v", st)
+	}
+}
+
+func TestStructureStatsConcurrentWithWriters(t *testing.T) {
+	m := NewOrdered[int, int]()
+	const total = 2000
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range total {
+			m.Put(i, i)
+			if i%3 == 0 {
+				m.Delete(i / 2)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			st := m.StructureStats()
+			heights := 0
+			for _, n := range st.Heights {
+				heights += n
+			}
+			if heights != st.Levels[0] {
+				t.Errorf("expected heights to add up to %d nodes, got %d", st.Levels[0], heights)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	st := m.StructureStats()
+	if int64(st.Levels[0]) != m.LenInt64() || st.Deleted != 0 {
+		t.Fatalf("expected %d linked nodes and none deleted once writers finish, got %d and %d", m.LenInt64(), st.Levels[0], st.Deleted)
+	}
+}
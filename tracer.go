@@ -0,0 +1,81 @@
+package skiplist
+
+import "sync/atomic"
+
+// CASKind tells a Tracer which structural update a CAS belongs to.
+type CASKind int
+
+const (
+	// CASInsert links a new node on one level.
+	CASInsert CASKind = iota
+	// CASUnlink swings a predecessor past a node that its deleter removes.
+	CASUnlink
+	// CASHelp swings a predecessor past a deleted node on a search's way.
+	CASHelp
+	// CASMark freezes the level-0 link of a deleted node.
+	CASMark
+)
+
+// Tracer receives a map's internal events, for debugging and for tests that
+// need to act at a precise point of an operation. It is set per map with
+// WithTracer. Its methods run synchronously on the goroutine doing the work,
+// from many goroutines at once, and get keys as any. A map without a tracer
+// skips the calls, so keys are not boxed either.
+//
+// Embed NopTracer to handle only some events.
+type Tracer interface {
+	// SearchStart and SearchEnd bracket each descent towards key, made by a
+	// lookup, a mutator or a cursor. found reports whether a live node holds
+	// key.
+	SearchStart(key any)
+	SearchEnd(key any, found bool)
+	// CASAttempt is called right before every CAS on a link, and CASSuccess
+	// or CASFailure right after it. key belongs to the node being linked,
+	// unlinked or marked on level.
+	CASAttempt(kind CASKind, key any, level int)
+	CASSuccess(kind CASKind, key any, level int)
+	CASFailure(kind CASKind, key any, level int)
+	// Mark is called after the level-0 link of the node holding key was
+	// marked, by its deleter or by a search on the deleter's behalf.
+	Mark(key any)
+	// HelpUnlink is called after a search unlinked the deleted node holding
+	// key from level.
+	HelpUnlink(key any, level int)
+	// LevelChosen is called with the tower height drawn for a new node.
+	LevelChosen(level int)
+}
+
+// NopTracer is a Tracer that ignores every event.
+type NopTracer struct{}
+
+func (NopTracer) SearchStart(any)              {}
+func (NopTracer) SearchEnd(any, bool)          {}
+func (NopTracer) CASAttempt(CASKind, any, int) {}
+func (NopTracer) CASSuccess(CASKind, any, int) {}
+func (NopTracer) CASFailure(CASKind, any, int) {}
+func (NopTracer) Mark(any)                     {}
+func (NopTracer) HelpUnlink(any, int)          {}
+func (NopTracer) LevelChosen(int)              {}
+
+// cas runs a CAS on level for the node holding key, reporting it to the
+// tracer if there is one.
+func (m *SkipListMap[K, V]) cas(kind CASKind, key K, level int, p *atomic.Pointer[link[K, V]], old, newLink *link[K, V]) bool {
+	if m.tracer == nil {
+		return p.CompareAndSwap(old, newLink)
+	}
+	m.tracer.CASAttempt(kind, key, level)
+	if !p.CompareAndSwap(old, newLink) {
+		m.tracer.CASFailure(kind, key, level)
+		return false
+	}
+	m.tracer.CASSuccess(kind, key, level)
+	return true
+}
+
+// helped records that a search unlinked the deleted node n from level.
+func (m *SkipListMap[K, V]) helped(n *node[K, V], level int) {
+	m.metrics.IncHelpUnlink()
+	if m.tracer != nil {
+		m.tracer.HelpUnlink(n.key, level)
+	}
+}
@@ -0,0 +1,129 @@
+package skiplist
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// funcTracer forwards the events it has a function for and drops the rest.
+type funcTracer struct {
+	NopTracer
+	searchEnd  func(key any, found bool)
+	casAttempt func(kind CASKind, key any, level int)
+	mark       func(key any)
+}
+
+func (f *funcTracer) SearchEnd(key any, found bool) {
+	if f.searchEnd != nil {
+		f.searchEnd(key, found)
+	}
+}
+
+func (f *funcTracer) CASAttempt(kind CASKind, key any, level int) {
+	if f.casAttempt != nil {
+		f.casAttempt(kind, key, level)
+	}
+}
+
+func (f *funcTracer) Mark(key any) {
+	if f.mark != nil {
+		f.mark(key)
+	}
+}
+
+// logTracer records every event as a line of text.
+type logTracer struct {
+	events []string
+}
+
+func (l *logTracer) add(format string, args ...any) {
+	l.events = append(l.events, fmt.Sprintf(format, args...))
+}
+
+func (l *logTracer) SearchStart(key any)           { l.add("search %v", key) }
+func (l *logTracer) SearchEnd(key any, found bool) { l.add("found %v %v", key, found) }
+func (l *logTracer) CASAttempt(kind CASKind, key any, level int) {
+	l.add("attempt %d %v %d", kind, key, level)
+}
+func (l *logTracer) CASSuccess(kind CASKind, key any, level int) {
+	l.add("success %d %v %d", kind, key, level)
+}
+func (l *logTracer) CASFailure(kind CASKind, key any, level int) {
+	l.add("failure %d %v %d", kind, key, level)
+}
+func (l *logTracer) Mark(key any)                  { l.add("mark %v", key) }
+func (l *logTracer) HelpUnlink(key any, level int) { l.add("help %v %d", key, level) }
+func (l *logTracer) LevelChosen(level int)         { l.add("level %d", level) }
+
+func TestTracerReportsInsertAndDelete(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	tr := &logTracer{}
+	m := NewWithOptions[int, int](less, WithMaxLevel(1), WithTracer(tr))
+
+	m.Put(1, 1)
+	m.Get(1)
+	m.Delete(1)
+
+	want := []string{
+		"search 1", "found 1 false", "level 1", "attempt 0 1 0", "success 0 1 0",
+		"search 1", "found 1 true",
+		"search 1", "found 1 true",
+		"attempt 3 1 0", "success 3 1 0", "mark 1", "attempt 1 1 0", "success 1 1 0",
+	}
+	if !slices.Equal(tr.events, want) {
+		t.Fatalf("expected events %q, got %q", want, tr.events)
+	}
+}
+
+func TestTracerReportsHelpUnlink(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	tr := &logTracer{}
+	m := NewWithOptions[int, int](less, WithMaxLevel(1), WithTracer(tr))
+	m.Put(1, 1)
+	m.Put(2, 2)
+
+	// Delete key 1 only logically, so the next search unlinks it.
+	_, n, _ := m.search(1)
+	m.mutator.logicalDelete(n)
+	tr.events = nil
+
+	if !m.Contains(2) {
+		t.Fatalf("expected Contains to find key 2")
+	}
+	want := []string{
+		"search 2", "attempt 3 1 0", "success 3 1 0", "mark 1",
+		"attempt 2 1 0", "success 2 1 0", "help 1 0", "found 2 true",
+	}
+	if !slices.Equal(tr.events, want) {
+		t.Fatalf("expected events %q, got %q", want, tr.events)
+	}
+}
+
+func TestTracerLevelChosenMatchesTowers(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	var levels []int
+	tr := &levelTracer{levels: &levels}
+	m := NewWithOptions[int, int](less, WithSeed(1), WithTracer(tr))
+	for i := range 64 {
+		m.Put(i, i)
+	}
+
+	if len(levels) != 64 {
+		t.Fatalf("expected 64 level choices, got %d", len(levels))
+	}
+	i := 0
+	for n := m.head.next[0].Load().node; n != m.tail; n = n.next[0].Load().node {
+		if len(n.next) != levels[i] {
+			t.Fatalf("expected key %d to have height %d, got %d", n.key, levels[i], len(n.next))
+		}
+		i++
+	}
+}
+
+type levelTracer struct {
+	NopTracer
+	levels *[]int
+}
+
+func (l levelTracer) LevelChosen(level int) { *l.levels = append(*l.levels, level) }
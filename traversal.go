@@ -2,33 +2,154 @@ package skiplist
 
 // findImpl: simplified traversal helper; mirrors original behavior but kept concise.
 func (m *SkipListMap[K, V]) findImpl(key K) (preds, succs []*node[K, V], found bool) {
-	preds = make([]*node[K, V], MaxLevel)
-	succs = make([]*node[K, V], MaxLevel)
+	preds = make([]*node[K, V], m.maxLevel)
+	succs = make([]*node[K, V], m.maxLevel)
+	found = m.findInto(key, preds, succs)
+	return preds, succs, found
+}
 
-	x := m.head
-	for i := MaxLevel - 1; i >= 0; i-- {
-		for {
-			ptr := x.next[i].Load()
-			var next *node[K, V]
-			if ptr != nil {
-				next = *ptr
-			}
-			if next == nil {
-				next = m.tail
-			}
+// findInto is findImpl writing into caller-provided buffers of at least
+// maxLevel entries. Mutators pass stack arrays so that retries allocate
+// nothing.
+func (m *SkipListMap[K, V]) findInto(key K, preds, succs []*node[K, V]) (found bool) {
+	top := m.topLevel()
+	// Levels at or above the current height were empty when the search
+	// started; an insert that links there after raising the height is caught
+	// by finishLevels validating these entries.
+	for i := m.maxLevel - 1; i >= top; i-- {
+		preds[i] = m.head
+		succs[i] = m.tail
+	}
 
-			// Skip markers or logically deleted nodes (help unlinking).
-			if next != m.tail {
-				if next.marker || next.val.Load() == nil {
-					succPtr := m.loadNextPtr(next, i)
-					if !x.next[i].CompareAndSwap(ptr, succPtr) {
-						continue
+	if m.tracer != nil {
+		m.tracer.SearchStart(key)
+	}
+	var match *node[K, V]
+	visited := 0
+retry:
+	for {
+		x := m.head
+		for i := top - 1; i >= 0; i-- {
+			for {
+				l := x.next[i].Load()
+				if l.marked() {
+					// x was deleted after we stepped onto it and takes no
+					// inserts any more; start over so its predecessor unlinks it.
+					continue retry
+				}
+				next := l.node
+
+				// Help unlink logically deleted nodes.
+				if next != m.tail && m.deleted(next) {
+					if m.cas(CASHelp, next.key, i, &x.next[i], l, m.loadNextPtr(next, i)) {
+						m.helped(next, i)
 					}
 					continue
 				}
+
+				visited++
+				if c := m.cmpKey(next, key, match); c >= 0 {
+					if c == 0 {
+						match = next
+					}
+					preds[i] = x
+					succs[i] = next
+					break
+				}
+				x = next
 			}
+		}
+		break
+	}
+	m.metrics.AddSearch(visited)
+
+	candidate := succs[0]
+	found = candidate == match && !m.deleted(candidate)
+	if m.tracer != nil {
+		m.tracer.SearchEnd(key, found)
+	}
+	return found
+}
+
+// cmpKey compares n's key with key. The tail sorts after every key, and
+// match, a node that already compared equal on a higher level, is not
+// compared again.
+func (m *SkipListMap[K, V]) cmpKey(n *node[K, V], key K, match *node[K, V]) int {
+	switch n {
+	case m.tail:
+		return 1
+	case match:
+		return 0
+	}
+	return m.compare(n.key, key)
+}
 
-			if next == m.tail || !m.less(next.key, key) {
+// findFrom is findInto starting from an earlier search for a smaller key,
+// whose results preds and succs still hold. It climbs from level 0 while the
+// remembered predecessor's successor lies before key, then walks down from
+// the level where it stopped as findInto does. Levels above that keep their
+// remembered entries; insert checks them again before linking. ok is false if
+// the remembered nodes cannot be used, in which case preds and succs hold
+// garbage and the caller must run findInto.
+func (m *SkipListMap[K, V]) findFrom(key K, preds, succs []*node[K, V]) (found, ok bool) {
+	top := m.topLevel()
+	lvl := 0
+	visited := 0
+	for {
+		if lvl == top {
+			// Key lies past every remembered level; a search from the head
+			// costs the same.
+			return false, false
+		}
+		p := preds[lvl]
+		if p == nil || p != m.head && (m.deleted(p) || m.compare(p.key, key) >= 0) {
+			return false, false
+		}
+		l := p.next[lvl].Load()
+		if l.marked() {
+			return false, false
+		}
+		visited++
+		if m.cmpKey(l.node, key, nil) >= 0 {
+			break
+		}
+		lvl++
+	}
+
+	if m.tracer != nil {
+		m.tracer.SearchStart(key)
+	}
+	var match *node[K, V]
+	x := preds[lvl]
+	for i := lvl; i >= 0; i-- {
+		// The remembered predecessor on this level may already be further
+		// right than where the level above left us.
+		if p := preds[i]; p != x && (x == m.head || p != m.head && m.compare(x.key, p.key) < 0) {
+			x = p
+		}
+		for {
+			l := x.next[i].Load()
+			if l.marked() {
+				if m.tracer != nil {
+					m.tracer.SearchEnd(key, false)
+				}
+				return false, false
+			}
+			next := l.node
+
+			// Help unlink logically deleted nodes.
+			if next != m.tail && m.deleted(next) {
+				if m.cas(CASHelp, next.key, i, &x.next[i], l, m.loadNextPtr(next, i)) {
+					m.helped(next, i)
+				}
+				continue
+			}
+
+			visited++
+			if c := m.cmpKey(next, key, match); c >= 0 {
+				if c == 0 {
+					match = next
+				}
 				preds[i] = x
 				succs[i] = next
 				break
@@ -37,72 +158,176 @@ func (m *SkipListMap[K, V]) findImpl(key K) (preds, succs []*node[K, V], found b
 		}
 	}
 
+	m.metrics.AddSearch(visited)
+
 	candidate := succs[0]
-	if candidate != nil && candidate != m.tail && candidate.key == key {
-		if candidate.val.Load() != nil {
-			found = true
+	found = candidate == match && !m.deleted(candidate)
+	if m.tracer != nil {
+		m.tracer.SearchEnd(key, found)
+	}
+	return found, true
+}
+
+// search is the read-only counterpart of findImpl. It descends the same way
+// and helps unlink deleted nodes, but only remembers the current position, so
+// it allocates nothing. It returns the level-0 predecessor and successor of
+// key.
+func (m *SkipListMap[K, V]) search(key K) (pred, succ *node[K, V], found bool) {
+	if m.tracer != nil {
+		m.tracer.SearchStart(key)
+	}
+	var match *node[K, V]
+	visited := 0
+retry:
+	for {
+		x := m.head
+		next := m.tail
+		for i := m.topLevel() - 1; i >= 0; i-- {
+			for {
+				l := x.next[i].Load()
+				if l.marked() {
+					continue retry
+				}
+				next = l.node
+
+				// Help unlink logically deleted nodes.
+				if next != m.tail && m.deleted(next) {
+					if m.cas(CASHelp, next.key, i, &x.next[i], l, m.loadNextPtr(next, i)) {
+						m.helped(next, i)
+					}
+					continue
+				}
+
+				visited++
+				if c := m.cmpKey(next, key, match); c >= 0 {
+					if c == 0 {
+						match = next
+					}
+					break
+				}
+				x = next
+			}
+		}
+
+		m.metrics.AddSearch(visited)
+		found = next == match && !m.deleted(next)
+		if m.tracer != nil {
+			m.tracer.SearchEnd(key, found)
 		}
+		return x, next, found
 	}
-	return preds, succs, found
 }
 
-func (m *SkipListMap[K, V]) loadNextPtrImpl(n *node[K, V], level int) **node[K, V] {
+// loadNextPtrImpl returns the link that replaces n in its predecessor on the
+// given level. On level 0 that is the link frozen by n's mark; a deleted node
+// that is not marked yet is marked on its deleter's behalf first.
+func (m *SkipListMap[K, V]) loadNextPtrImpl(n *node[K, V], level int) *link[K, V] {
 	if n == nil || level >= len(n.next) {
-		return &m.tail
+		return m.toTail
 	}
-	succ := n.next[level].Load()
-	if succ == nil {
-		return &m.tail
+	l := n.next[level].Load()
+	if l == nil {
+		return m.toTail
 	}
-	next := *succ
-	if next == nil || !next.marker {
-		return succ
+	if level == 0 && !l.marked() && m.deleted(n) {
+		l, _ = m.mark(n)
 	}
-	if level >= len(next.next) {
-		return &m.tail
+	if l.marked() {
+		return l.unmarked
 	}
-	markerSucc := next.next[level].Load()
-	if markerSucc != nil {
-		return markerSucc
+	return l
+}
+
+// mark freezes n's level-0 successor by swapping in a marked copy of its
+// link. It returns the marked link and whether this call installed it.
+func (m *SkipListMap[K, V]) mark(n *node[K, V]) (*link[K, V], bool) {
+	for {
+		l := n.next[0].Load()
+		if l.marked() {
+			return l, false
+		}
+		marked := &link[K, V]{node: l.node, unmarked: l}
+		if m.cas(CASMark, n.key, 0, &n.next[0], l, marked) {
+			m.metrics.IncMark()
+			if m.tracer != nil {
+				m.tracer.Mark(n.key)
+			}
+			return marked, true
+		}
 	}
-	return &m.tail
 }
 
+// advanceFromImpl returns the first live node after start on level 0, or nil
+// at the end of the list. A nil start means the head.
 func (m *SkipListMap[K, V]) advanceFromImpl(start *node[K, V]) *node[K, V] {
 	base := start
+	if base == nil {
+		base = m.head
+	}
 	for {
-		if base == nil {
-			base = m.head
-		}
-		if base == nil || len(base.next) == 0 {
-			return nil
-		}
-		ptr := base.next[0].Load()
-		if ptr == nil {
+		l := base.next[0].Load()
+		next := l.node
+		if next == m.tail {
 			return nil
 		}
-		next := *ptr
-		if next == nil {
-			if base.next[0].CompareAndSwap(ptr, &m.tail) {
-				continue
+		if m.deleted(next) {
+			m.search(next.key)
+			if base.next[0].Load() == l {
+				// base is frozen by its own deletion, so the search could
+				// not unlink next from it; step over next instead.
+				base = next
 			}
 			continue
 		}
-		if next == m.tail {
-			return nil
-		}
-		if next.marker {
-			succPtr := next.next[0].Load()
-			if succPtr == nil {
-				succPtr = &m.tail
+		return next
+	}
+}
+
+// findLast descends to the last node on level 0, helping unlink logically
+// deleted nodes on the way. It returns the head sentinel when the list is
+// empty.
+func (m *SkipListMap[K, V]) findLast() *node[K, V] {
+retry:
+	for {
+		x := m.head
+		for i := m.topLevel() - 1; i >= 0; i-- {
+			for {
+				l := x.next[i].Load()
+				if l.marked() {
+					continue retry
+				}
+				next := l.node
+				if next == m.tail {
+					break
+				}
+
+				if m.deleted(next) {
+					if m.cas(CASHelp, next.key, i, &x.next[i], l, m.loadNextPtr(next, i)) {
+						m.helped(next, i)
+					}
+					continue
+				}
+				x = next
 			}
-			base.next[0].CompareAndSwap(ptr, succPtr)
-			continue
 		}
-		if next.val.Load() == nil {
-			m.find(next.key)
-			continue
+		return x
+	}
+}
+
+// topLevel returns the number of levels that currently hold nodes. Searches
+// start there instead of at maxLevel-1.
+func (m *SkipListMap[K, V]) topLevel() int {
+	return int(m.height.Load())
+}
+
+// raiseHeight lifts the current height to at least h. Inserts call it before
+// linking a tower of height h, so any search that starts after a node appears
+// on a level also walks that level.
+func (m *SkipListMap[K, V]) raiseHeight(h int) {
+	for {
+		cur := m.height.Load()
+		if int(cur) >= h || m.height.CompareAndSwap(cur, int32(h)) {
+			return
 		}
-		return next
 	}
 }
@@ -0,0 +1,73 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCorrupted is returned by Validate, wrapped with a description of the
+// first broken invariant.
+var ErrCorrupted = errors.New("skiplist: corrupted structure")
+
+// Validate checks the map's invariants and reports the first one that fails,
+// naming the keys and level involved:
+//
+//   - keys strictly increase along every level;
+//   - every level above 0 links a subsequence of level 0, and only nodes
+//     whose tower reaches that level;
+//   - towers are between 1 and the configured maximum level high, and no
+//     level at or above the current height holds nodes;
+//   - only deleted nodes have a marked link;
+//   - the live nodes on level 0 number LenInt64.
+//
+// Validate only reads links. Writers that run at the same time can make it
+// report their intermediate steps as corruption, so call it while the map is
+// quiescent.
+func (m *SkipListMap[K, V]) Validate() error {
+	pos := make(map[*node[K, V]]int)
+	live := 0
+	var prev *node[K, V]
+	for n := m.head.next[0].Load().node; n != m.tail; {
+		if h := len(n.next); h < 1 || h > m.maxLevel {
+			return fmt.Errorf("%w: key %v has a tower of height %d outside [1, %d]", ErrCorrupted, n.key, h, m.maxLevel)
+		}
+		if prev != nil && m.compare(prev.key, n.key) >= 0 {
+			return fmt.Errorf("%w: level 0: key %v follows %v", ErrCorrupted, n.key, prev.key)
+		}
+		l := n.next[0].Load()
+		deleted := m.deleted(n)
+		if l.marked() && !deleted {
+			return fmt.Errorf("%w: level 0: key %v has a marked link but is not deleted", ErrCorrupted, n.key)
+		}
+		if !deleted {
+			live++
+		}
+		pos[n] = len(pos)
+		prev, n = n, l.node
+	}
+	if length := m.LenInt64(); int64(live) != length {
+		return fmt.Errorf("%w: level 0 holds %d live keys, LenInt64 reports %d", ErrCorrupted, live, length)
+	}
+
+	top := m.topLevel()
+	for level := 1; level < m.maxLevel; level++ {
+		prev = nil
+		for n := m.head.next[level].Load().node; n != m.tail; n = n.next[level].Load().node {
+			if level >= top {
+				return fmt.Errorf("%w: level %d: key %v is linked above the height %d", ErrCorrupted, level, n.key, top)
+			}
+			if level >= len(n.next) {
+				return fmt.Errorf("%w: level %d: key %v has a tower of height %d", ErrCorrupted, level, n.key, len(n.next))
+			}
+			i, ok := pos[n]
+			if !ok {
+				return fmt.Errorf("%w: level %d: key %v is not linked on level 0", ErrCorrupted, level, n.key)
+			}
+			if prev != nil && i <= pos[prev] {
+				return fmt.Errorf("%w: level %d: key %v follows %v", ErrCorrupted, level, n.key, prev.key)
+			}
+			prev = n
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package skiplist
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateAcceptsHealthyMap(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := New[int, int](less)
+	if err := m.Validate(); err != nil {
+		t.Fatalf("expected empty map to validate, got %v", err)
+	}
+	for i := range 500 {
+		m.Put(i, i)
+	}
+	for i := 0; i < 500; i += 3 {
+		m.Delete(i)
+	}
+	m.DeleteRange(100, 200)
+	m.PopMin()
+	if err := m.Validate(); err != nil {
+		t.Fatalf("expected map to validate, got %v", err)
+	}
+
+	// A node that is deleted but still linked is a legal intermediate state.
+	_, n, _ := m.search(300)
+	m.mutator.logicalDelete(n)
+	m.mark(n)
+	if err := m.Validate(); err != nil {
+		t.Fatalf("expected a deleted, linked node to validate, got %v", err)
+	}
+}
+
+func TestValidateReportsBrokenInvariants(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	cases := map[string]struct {
+		corrupt func(m *SkipListMap[int, int])
+		want    string
+	}{
+		"level 0 order": {
+			corrupt: func(m *SkipListMap[int, int]) {
+				_, n, _ := m.search(5)
+				n.key = 50
+			},
+			want: "level 0: key 6 follows 50",
+		},
+		"marked live node": {
+			corrupt: func(m *SkipListMap[int, int]) {
+				_, n, _ := m.search(3)
+				m.mark(n)
+			},
+			want: "level 0: key 3 has a marked link but is not deleted",
+		},
+		"length": {
+			corrupt: func(m *SkipListMap[int, int]) { m.metrics.AddLen(1) },
+			want:    "level 0 holds 10 live keys, LenInt64 reports 11",
+		},
+		"not on level 0": {
+			corrupt: func(m *SkipListMap[int, int]) {
+				v := 0
+				stray := newNode(100, &v, 2)
+				stray.next[1].Store(m.head.next[1].Load())
+				m.head.next[1].Store(linkTo(stray))
+			},
+			want: "level 1: key 100 is not linked on level 0",
+		},
+		"short tower": {
+			corrupt: func(m *SkipListMap[int, int]) {
+				_, n, _ := m.search(0)
+				m.head.next[1].Store(linkTo(n))
+			},
+			want: "level 1: key 0 has a tower of height 1",
+		},
+		"upper level order": {
+			corrupt: func(m *SkipListMap[int, int]) {
+				_, n1, _ := m.search(1)
+				_, n3, _ := m.search(3)
+				m.head.next[1].Store(linkTo(n3))
+				n3.next[1].Store(linkTo(n1))
+				n1.next[1].Store(m.toTail)
+			},
+			want: "level 1: key 1 follows 3",
+		},
+		"above height": {
+			corrupt: func(m *SkipListMap[int, int]) { m.height.Store(1) },
+			want:    "level 1: key 1 is linked above the height 1",
+		},
+	}
+
+	for name, tc := range cases {
+		// Keys 1, 3, 5, 7 and 9 reach level 1, with 3 and 7 going higher.
+		m, err := FromSorted(less, sortedInts(0, 1, 2, 3, 4, 5, 6, 7, 8, 9), WithMaxLevel(4))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := m.Validate(); err != nil {
+			t.Fatalf("%s: expected the map to validate before corruption, got %v", name, err)
+		}
+
+		tc.corrupt(m)
+		err = m.Validate()
+		if !errors.Is(err, ErrCorrupted) || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, tc.want, err)
+		}
+	}
+}
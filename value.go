@@ -0,0 +1,171 @@
+package skiplist
+
+import (
+	"reflect"
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Values are stored in one of two ways. Boxed storage keeps a *V in node.val
+// and a nil box marks the node deleted, so every write allocates a box.
+// Inline storage keeps small pointer-free values in inlineNode.inline instead,
+// guarded by inlineNode.state: a seqlock word that also carries the deleted
+// flag. Updates then write the words in place and allocate nothing.
+const (
+	// stateLocked is set while a writer rewrites the inline words.
+	stateLocked = 1 << 0
+	// stateDeleted marks the node logically deleted; it is never cleared.
+	stateDeleted = 1 << 1
+	// stateStep advances the version on every write.
+	stateStep = 1 << 2
+)
+
+// inlineWords is the largest value, in 8-byte words, kept inline.
+const inlineWords = 2
+
+// inlineNode is the layout of every node in a map with inline storage,
+// including the sentinels. The map handles it through its embedded node, so
+// boxed maps do not pay for the extra words.
+type inlineNode[K, V any] struct {
+	node[K, V]
+	state  atomic.Uint64
+	inline [inlineWords]atomic.Uint64
+}
+
+// inlined returns the inlineNode that n is embedded in. n must belong to a map
+// with inline storage.
+func (n *node[K, V]) inlined() *inlineNode[K, V] {
+	return (*inlineNode[K, V])(unsafe.Pointer(n))
+}
+
+// valueRef identifies the value a writer loaded. Passing it back to
+// swapValue or deleteValue makes the write fail if the value changed since.
+type valueRef[V any] struct {
+	box   *V
+	state uint64
+}
+
+// fitsInline reports whether values of type V can use inline storage. The
+// inline words are invisible to the garbage collector, so V must hold no
+// pointers.
+func fitsInline[V any]() bool {
+	t := reflect.TypeFor[V]()
+	return t.Size() <= inlineWords*8 && t.Align() <= 8 && !hasPointers(t)
+}
+
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return t.Len() > 0 && hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// newNode returns an unlinked node holding value in the map's storage mode.
+func (m *SkipListMap[K, V]) newNode(key K, value V, level int) *node[K, V] {
+	if !m.inline {
+		return newNode(key, box(value), level)
+	}
+	in := &inlineNode[K, V]{}
+	in.key = key
+	in.next = make([]atomic.Pointer[link[K, V]], level)
+	in.storeInline(value)
+	return &in.node
+}
+
+// deleted reports whether n is logically deleted.
+func (m *SkipListMap[K, V]) deleted(n *node[K, V]) bool {
+	if m.inline {
+		return n.inlined().state.Load()&stateDeleted != 0
+	}
+	return n.val.Load() == nil
+}
+
+// loadValue returns n's value and a reference for a later swapValue or
+// deleteValue. ok is false if n is deleted. An inline read waits for a
+// writer that holds the seqlock and retries if the version moved while it
+// copied the words.
+func (m *SkipListMap[K, V]) loadValue(n *node[K, V]) (v V, ref valueRef[V], ok bool) {
+	if !m.inline {
+		p := n.val.Load()
+		if p == nil {
+			return v, ref, false
+		}
+		return *p, valueRef[V]{box: p}, true
+	}
+	in := n.inlined()
+	for {
+		s := in.state.Load()
+		if s&stateDeleted != 0 {
+			return v, ref, false
+		}
+		if s&stateLocked != 0 {
+			runtime.Gosched()
+			continue
+		}
+		v = in.loadInline()
+		if in.state.Load() == s {
+			return v, valueRef[V]{state: s}, true
+		}
+	}
+}
+
+// swapValue stores v in n if n still holds the value ref was loaded with.
+func (m *SkipListMap[K, V]) swapValue(n *node[K, V], ref valueRef[V], v V) bool {
+	if !m.inline {
+		return n.val.CompareAndSwap(ref.box, box(v))
+	}
+	in := n.inlined()
+	if !in.state.CompareAndSwap(ref.state, ref.state|stateLocked) {
+		return false
+	}
+	in.storeInline(v)
+	in.state.Store(ref.state + stateStep)
+	return true
+}
+
+// deleteValue logically deletes n if it still holds the value ref was loaded
+// with.
+func (m *SkipListMap[K, V]) deleteValue(n *node[K, V], ref valueRef[V]) bool {
+	if !m.inline {
+		return n.val.CompareAndSwap(ref.box, nil)
+	}
+	return n.inlined().state.CompareAndSwap(ref.state, (ref.state+stateStep)|stateDeleted)
+}
+
+// box copies v to the heap. Taking &v directly would move v to the heap on
+// the inline path as well.
+func box[V any](v V) *V {
+	p := new(V)
+	*p = v
+	return p
+}
+
+func (n *inlineNode[K, V]) storeInline(v V) {
+	var words [inlineWords]uint64
+	*(*V)(unsafe.Pointer(&words)) = v
+	for i := range words {
+		n.inline[i].Store(words[i])
+	}
+}
+
+func (n *inlineNode[K, V]) loadInline() V {
+	var words [inlineWords]uint64
+	for i := range words {
+		words[i] = n.inline[i].Load()
+	}
+	return *(*V)(unsafe.Pointer(&words))
+}
@@ -0,0 +1,124 @@
+package skiplist
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+type pair struct {
+	A, B int64
+}
+
+func TestInlineValuesOperations(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := NewWithOptions[int, pair](less, WithInlineValues(true))
+
+	for i := range 10 {
+		m.Put(i, pair{int64(i), int64(-i)})
+	}
+	if old, replaced := m.Put(3, pair{30, 30}); !replaced || old != (pair{3, -3}) {
+		t.Fatalf("expected Put to replace (3, -3), got %v, %v", old, replaced)
+	}
+	if got, ok := m.Get(3); !ok || got != (pair{30, 30}) {
+		t.Fatalf("expected Get(3) = {30 30}, got %v, %v", got, ok)
+	}
+	if !CompareAndSwap(m, 4, pair{4, -4}, pair{40, 40}) {
+		t.Fatalf("expected CompareAndSwap to succeed on the current value")
+	}
+	if CompareAndSwap(m, 4, pair{4, -4}, pair{0, 0}) {
+		t.Fatalf("expected CompareAndSwap to fail on a stale value")
+	}
+	if old, ok := m.Delete(5); !ok || old != (pair{5, -5}) {
+		t.Fatalf("expected Delete to return (5, -5), got %v, %v", old, ok)
+	}
+	if m.Contains(5) {
+		t.Fatalf("expected deleted key to be absent")
+	}
+	if _, ok := m.Compute(6, func(pair, bool) (pair, Op) { return pair{}, OpDelete }); ok {
+		t.Fatalf("expected Compute delete to report absence")
+	}
+	if k, v, ok := m.PopMin(); !ok || k != 0 || v != (pair{}) {
+		t.Fatalf("expected PopMin to return key 0, got %d, %v, %v", k, v, ok)
+	}
+
+	var keys []int
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		if want, _ := m.Get(k); v != want {
+			t.Fatalf("iterator value for %d = %v, want %v", k, v, want)
+		}
+	}
+	if want := []int{1, 2, 3, 4, 7, 8, 9}; !slices.Equal(keys, want) {
+		t.Fatalf("expected keys %v, got %v", want, keys)
+	}
+	if got := m.LenInt64(); got != 7 {
+		t.Fatalf("expected length 7, got %d", got)
+	}
+}
+
+func TestInlineValuesRejectsUnsuitableTypes(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	cases := map[string]func(){
+		"string":  func() { NewWithOptions[int, string](less, WithInlineValues(true)) },
+		"pointer": func() { NewWithOptions[int, *int](less, WithInlineValues(true)) },
+		"large":   func() { NewWithOptions[int, [3]int64](less, WithInlineValues(true)) },
+		"nested":  func() { NewWithOptions[int, struct{ p []byte }](less, WithInlineValues(true)) },
+	}
+	for name, fn := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected NewWithOptions to panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestInlineValuesReadsAreNotTorn(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	m := NewWithOptions[int, pair](less, WithInlineValues(true))
+	const keys = 8
+
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 2000 {
+				k := i % keys
+				v := int64(w*10000 + i)
+				if i%7 == 0 {
+					m.Delete(k)
+					continue
+				}
+				m.Put(k, pair{v, v})
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 4000 {
+				if v, ok := m.Get(i % keys); ok && v.A != v.B {
+					t.Errorf("torn read: %v", v)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBoxedNodesHaveNoInlineWords(t *testing.T) {
+	// key, value box and tower slice.
+	var n node[int, int]
+	want := unsafe.Sizeof(n.key) + unsafe.Sizeof(n.val) + unsafe.Sizeof(n.next)
+	if got := unsafe.Sizeof(n); got != want {
+		t.Fatalf("expected boxed node of %d bytes, got %d", want, got)
+	}
+}
@@ -0,0 +1,379 @@
+package skiplist
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+// version is one entry in a key's version chain. Chains are ordered newest
+// first and their sequence numbers strictly decrease along prev.
+type version[V any] struct {
+	// seq is zero until the writer (or a helping reader) assigns it.
+	seq       atomic.Uint64
+	val       V
+	tombstone bool
+	prev      atomic.Pointer[version[V]]
+}
+
+// VersionedMap is a SkipListMap that keeps a short version chain per key so
+// that Snapshot can serve point-in-time reads. Every Put and Delete receives
+// a sequence number from a global counter; a snapshot sees exactly the
+// versions whose sequence is at or below its own.
+type VersionedMap[K comparable, V any] struct {
+	m *SkipListMap[K, *version[V]]
+	// seq is the global sequence counter.
+	seq atomic.Uint64
+	// horizon is the oldest sequence a new snapshot may pin; versions that
+	// are shadowed at the horizon may be dropped. It only moves forward.
+	horizon atomic.Uint64
+	// pins counts open snapshots per sequence number.
+	pins *SkipListMap[uint64, int]
+	// retired holds the keys whose chains still hold versions that a
+	// snapshot needed when the key was last written. Each key appears once
+	// however often it is written.
+	retired *SkipListMap[K, struct{}]
+	length  atomic.Int64
+}
+
+// NewVersioned returns a new VersionedMap.
+func NewVersioned[K comparable, V any](less Less[K]) *VersionedMap[K, V] {
+	return &VersionedMap[K, V]{
+		m:       New[K, *version[V]](less),
+		pins:    New[uint64, int](func(a, b uint64) bool { return a < b }),
+		retired: New[K, struct{}](less),
+	}
+}
+
+// Get returns the latest value for a key.
+// The boolean is true if the key exists, false otherwise.
+func (vm *VersionedMap[K, V]) Get(key K) (V, bool) {
+	head, ok := vm.m.Get(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	// Number the version before reporting it, so that a snapshot taken
+	// after this read sees it too.
+	vm.seqOf(head)
+	if head.tombstone {
+		var zero V
+		return zero, false
+	}
+	return head.val, true
+}
+
+// Contains returns true if the key currently exists.
+func (vm *VersionedMap[K, V]) Contains(key K) bool {
+	_, ok := vm.Get(key)
+	return ok
+}
+
+// Put records a new version for the key.
+// It returns the previous value and a flag indicating whether an existing entry was replaced.
+func (vm *VersionedMap[K, V]) Put(key K, value V) (V, bool) {
+	ver := &version[V]{val: value}
+	var old *version[V]
+	vm.m.Compute(key, func(cur *version[V], exists bool) (*version[V], Op) {
+		old = nil
+		if exists {
+			old = cur
+			// Publish the predecessor's sequence first so that ver, which
+			// is numbered after it is linked, always sorts above it.
+			vm.seqOf(cur)
+		}
+		ver.prev.Store(old)
+		return ver, OpUpdate
+	})
+	vm.seqOf(ver)
+
+	var prev V
+	replaced := old != nil && !old.tombstone
+	if replaced {
+		prev = old.val
+	} else {
+		vm.length.Add(1)
+	}
+	vm.trim(key, ver)
+	return prev, replaced
+}
+
+// Delete records a tombstone version for the key.
+// It returns the removed value and true if the key existed.
+func (vm *VersionedMap[K, V]) Delete(key K) (V, bool) {
+	ver := &version[V]{tombstone: true}
+	var old *version[V]
+	vm.m.Compute(key, func(cur *version[V], exists bool) (*version[V], Op) {
+		old = nil
+		if !exists || cur.tombstone {
+			return nil, OpKeep
+		}
+		old = cur
+		vm.seqOf(cur)
+		ver.prev.Store(cur)
+		return ver, OpUpdate
+	})
+
+	if old == nil {
+		var zero V
+		return zero, false
+	}
+	vm.seqOf(ver)
+	vm.length.Add(-1)
+	vm.trim(key, ver)
+	return old.val, true
+}
+
+// LenInt64 returns the number of live keys.
+func (vm *VersionedMap[K, V]) LenInt64() int64 {
+	return vm.length.Load()
+}
+
+// All returns an iterator over the latest value of every live key in
+// ascending key order.
+func (vm *VersionedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, head := range vm.m.All() {
+			vm.seqOf(head)
+			if head.tombstone {
+				continue
+			}
+			if !yield(k, head.val) {
+				return
+			}
+		}
+	}
+}
+
+// Snapshot returns a read view pinned at the current sequence number. The
+// caller must call Release once the snapshot is no longer needed so that the
+// versions it keeps alive can be dropped.
+func (vm *VersionedMap[K, V]) Snapshot() *Snapshot[K, V] {
+	for {
+		seq := vm.seq.Load()
+		vm.pins.Compute(seq, func(n int, _ bool) (int, Op) { return n + 1, OpUpdate })
+		// A writer that raised the horizon past seq before our pin became
+		// visible may already have dropped versions we need; pin again.
+		if vm.horizon.Load() <= seq {
+			return &Snapshot[K, V]{vm: vm, seq: seq}
+		}
+		vm.unpin(seq)
+	}
+}
+
+func (vm *VersionedMap[K, V]) unpin(seq uint64) {
+	vm.pins.Compute(seq, func(n int, _ bool) (int, Op) {
+		if n <= 1 {
+			return 0, OpDelete
+		}
+		return n - 1, OpUpdate
+	})
+}
+
+// seqOf returns the version's sequence number, assigning a fresh one on the
+// writer's behalf if it has not been published yet.
+func (vm *VersionedMap[K, V]) seqOf(ver *version[V]) uint64 {
+	if seq := ver.seq.Load(); seq != 0 {
+		return seq
+	}
+	ver.seq.CompareAndSwap(0, vm.seq.Add(1))
+	return ver.seq.Load()
+}
+
+// safePoint raises the horizon and returns the oldest sequence that any open
+// or future snapshot may read at.
+func (vm *VersionedMap[K, V]) safePoint() uint64 {
+	point := vm.seq.Load()
+	if oldest, _, ok := vm.pins.First(); ok && oldest < point {
+		point = oldest
+	}
+	for {
+		cur := vm.horizon.Load()
+		if cur >= point || vm.horizon.CompareAndSwap(cur, point) {
+			break
+		}
+	}
+	// A snapshot that pinned before observing the raised horizon is still
+	// honored here.
+	if oldest, _, ok := vm.pins.First(); ok && oldest < point {
+		point = oldest
+	}
+	return point
+}
+
+// trim drops the versions of key that no snapshot can reach, starting from
+// head. A tombstone that every snapshot sees removes the node altogether.
+// Keys whose history is still pinned are queued until a snapshot is released.
+func (vm *VersionedMap[K, V]) trim(key K, head *version[V]) {
+	point := vm.safePoint()
+	ver := head
+	for ver != nil && vm.seqOf(ver) > point {
+		ver = ver.prev.Load()
+	}
+	if ver != nil {
+		// Every snapshot reads at or above point, so none looks past ver.
+		ver.prev.Store(nil)
+	}
+	if ver == head {
+		if head.tombstone {
+			vm.m.CompareAndDeleteFunc(key, head, func(a, b *version[V]) bool { return a == b })
+		}
+		return
+	}
+	if ver == nil && !head.tombstone && head.prev.Load() == nil {
+		// A lone live version has nothing to drop later.
+		return
+	}
+	vm.retire(key)
+}
+
+func (vm *VersionedMap[K, V]) retire(key K) {
+	vm.retired.PutIfAbsent(key, struct{}{})
+}
+
+// releaseRetired trims every queued key again; keys still pinned are queued
+// once more by trim.
+func (vm *VersionedMap[K, V]) releaseRetired() {
+	for key := range vm.retired.Keys() {
+		// Dequeue before trimming, so a write racing with the trim queues
+		// the key again instead of being lost.
+		vm.retired.Delete(key)
+		if head, ok := vm.m.Get(key); ok {
+			vm.trim(key, head)
+		}
+	}
+}
+
+// Snapshot is a point-in-time read view of a VersionedMap.
+type Snapshot[K comparable, V any] struct {
+	vm       *VersionedMap[K, V]
+	seq      uint64
+	released atomic.Bool
+}
+
+// Seq returns the sequence number the snapshot reads at.
+func (s *Snapshot[K, V]) Seq() uint64 {
+	return s.seq
+}
+
+// Release unpins the snapshot so that versions only it could see are
+// dropped. Calling Release more than once has no effect.
+func (s *Snapshot[K, V]) Release() {
+	if !s.released.CompareAndSwap(false, true) {
+		return
+	}
+	s.vm.unpin(s.seq)
+	s.vm.releaseRetired()
+}
+
+// Get returns the value the key had at the snapshot's sequence number.
+func (s *Snapshot[K, V]) Get(key K) (V, bool) {
+	head, ok := s.vm.m.Get(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return s.visible(head)
+}
+
+// Contains reports whether the key existed at the snapshot's sequence number.
+func (s *Snapshot[K, V]) Contains(key K) bool {
+	_, ok := s.Get(key)
+	return ok
+}
+
+// visible returns the newest value in the chain at or below the snapshot.
+func (s *Snapshot[K, V]) visible(head *version[V]) (V, bool) {
+	var zero V
+	for ver := head; ver != nil; ver = ver.prev.Load() {
+		if s.vm.seqOf(ver) > s.seq {
+			continue
+		}
+		if ver.tombstone {
+			return zero, false
+		}
+		return ver.val, true
+	}
+	return zero, false
+}
+
+// All returns an iterator over every key visible in the snapshot in
+// ascending key order.
+func (s *Snapshot[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it := s.Iterator()
+		for it.Next() {
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Iterator returns a new iterator over the snapshot positioned before the
+// first element.
+func (s *Snapshot[K, V]) Iterator() *SnapshotIterator[K, V] {
+	return &SnapshotIterator[K, V]{s: s, it: s.vm.m.Iterator()}
+}
+
+// SnapshotIterator walks the keys visible in a Snapshot. It uses the same
+// traversal as Iterator and skips keys whose visible version is absent.
+type SnapshotIterator[K comparable, V any] struct {
+	s     *Snapshot[K, V]
+	it    *Iterator[K, *version[V]]
+	value V
+}
+
+// Valid reports whether the iterator currently points at an element.
+func (si *SnapshotIterator[K, V]) Valid() bool {
+	return si.it.Valid()
+}
+
+// Key returns the key at the iterator's current position.
+// It should only be called when Valid reports true.
+func (si *SnapshotIterator[K, V]) Key() K {
+	return si.it.Key()
+}
+
+// Value returns the value at the iterator's current position as of the
+// snapshot. It should only be called when Valid reports true.
+func (si *SnapshotIterator[K, V]) Value() V {
+	var zero V
+	if !si.it.Valid() {
+		return zero
+	}
+	return si.value
+}
+
+// SeekGE positions the iterator at the first visible element whose key is
+// greater than or equal to the provided key.
+func (si *SnapshotIterator[K, V]) SeekGE(key K) bool {
+	return si.settle(si.it.SeekGE(key), si.it.Next)
+}
+
+// SeekLT positions the iterator at the last visible element whose key is
+// strictly less than the provided key.
+func (si *SnapshotIterator[K, V]) SeekLT(key K) bool {
+	return si.settle(si.it.SeekLT(key), si.it.Prev)
+}
+
+// Next advances the iterator to the next visible element.
+func (si *SnapshotIterator[K, V]) Next() bool {
+	return si.settle(si.it.Next(), si.it.Next)
+}
+
+// Prev moves the iterator to the previous visible element.
+func (si *SnapshotIterator[K, V]) Prev() bool {
+	return si.settle(si.it.Prev(), si.it.Prev)
+}
+
+// settle steps with step until the underlying iterator rests on a key that
+// has a visible version, or runs out of elements.
+func (si *SnapshotIterator[K, V]) settle(ok bool, step func() bool) bool {
+	for ; ok; ok = step() {
+		if v, visible := si.s.visible(si.it.Value()); visible {
+			si.value = v
+			return true
+		}
+	}
+	return false
+}
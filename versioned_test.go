@@ -0,0 +1,211 @@
+package skiplist
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSnapshotSeesPointInTimeView(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	vm := NewVersioned[int, string](less)
+
+	vm.Put(1, "one")
+	vm.Put(2, "two")
+
+	snap := vm.Snapshot()
+	defer snap.Release()
+
+	vm.Put(1, "uno")
+	vm.Delete(2)
+	vm.Put(3, "three")
+
+	if got, ok := snap.Get(1); !ok || got != "one" {
+		t.Fatalf("expected snapshot to read (one, true) for key 1, got (%q, %v)", got, ok)
+	}
+	if got, ok := snap.Get(2); !ok || got != "two" {
+		t.Fatalf("expected snapshot to read deleted key 2, got (%q, %v)", got, ok)
+	}
+	if snap.Contains(3) {
+		t.Fatalf("expected snapshot not to see key inserted after it")
+	}
+
+	var keys []int
+	for k, v := range snap.All() {
+		keys = append(keys, k)
+		if want, _ := snap.Get(k); v != want {
+			t.Fatalf("iterator value %q disagrees with snapshot Get %q", v, want)
+		}
+	}
+	if !slices.Equal(keys, []int{1, 2}) {
+		t.Fatalf("expected snapshot keys [1 2], got %v", keys)
+	}
+
+	if got, ok := vm.Get(1); !ok || got != "uno" {
+		t.Fatalf("expected live read (uno, true), got (%q, %v)", got, ok)
+	}
+	if vm.Contains(2) {
+		t.Fatalf("expected live view to miss deleted key")
+	}
+	if gotLen := vm.LenInt64(); gotLen != 2 {
+		t.Fatalf("expected live length 2, got %d", gotLen)
+	}
+
+	it := snap.Iterator()
+	if !it.SeekLT(3) || it.Key() != 2 || it.Value() != "two" {
+		t.Fatalf("expected SeekLT(3) on snapshot to land on (2, two)")
+	}
+	if !it.Prev() || it.Key() != 1 || it.Value() != "one" {
+		t.Fatalf("expected Prev on snapshot to land on (1, one)")
+	}
+}
+
+func TestSnapshotSeesVersionReadBeforeIt(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	vm := NewVersioned[int, int](less)
+	vm.Put(1, 10)
+
+	// Link a version the way Put does, but stop before numbering it, as a
+	// writer that has not returned from Compute yet.
+	head, _ := vm.m.Get(1)
+	ver := &version[int]{val: 20}
+	ver.prev.Store(head)
+	vm.m.Put(1, ver)
+
+	if got, ok := vm.Get(1); !ok || got != 20 {
+		t.Fatalf("expected live read (20, true), got (%d, %v)", got, ok)
+	}
+	snap := vm.Snapshot()
+	defer snap.Release()
+	if got, ok := snap.Get(1); !ok || got != 20 {
+		t.Fatalf("expected snapshot after the live read to see 20, got (%d, %v)", got, ok)
+	}
+}
+
+func TestSnapshotReleaseDropsOldVersions(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	vm := NewVersioned[int, int](less)
+
+	vm.Put(1, 1)
+	vm.Put(2, 2)
+	snap := vm.Snapshot()
+	for i := range 10 {
+		vm.Put(1, i)
+	}
+	vm.Delete(2)
+
+	if head, _ := vm.m.Get(1); chainLen(head) != 11 {
+		t.Fatalf("expected pinned chain of 11 versions, got %d", chainLen(head))
+	}
+	if !vm.m.Contains(2) {
+		t.Fatalf("expected pinned tombstone to keep the node linked")
+	}
+
+	snap.Release()
+	snap.Release()
+
+	if head, _ := vm.m.Get(1); chainLen(head) != 1 {
+		t.Fatalf("expected chain to shrink to 1 version after release, got %d", chainLen(head))
+	}
+	if vm.m.Contains(2) {
+		t.Fatalf("expected tombstoned key to be removed after release")
+	}
+	if _, _, ok := vm.pins.First(); ok {
+		t.Fatalf("expected no pinned sequences after release")
+	}
+
+	vm.Put(1, 42)
+	if head, _ := vm.m.Get(1); chainLen(head) != 1 {
+		t.Fatalf("expected unpinned writes to keep a single version, got %d", chainLen(head))
+	}
+}
+
+func TestSnapshotRetiresEachKeyOnce(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	vm := NewVersioned[int, int](less)
+
+	vm.Put(1, 0)
+	snap := vm.Snapshot()
+	for i := range 1000 {
+		vm.Put(1, i)
+	}
+	if got := vm.retired.LenInt64(); got != 1 {
+		t.Fatalf("expected one retired key while the snapshot is open, got %d", got)
+	}
+
+	other := vm.Snapshot()
+	vm.Put(1, -1)
+	snap.Release()
+	if got := vm.retired.LenInt64(); got != 1 {
+		t.Fatalf("expected the key to stay retired once while pinned, got %d", got)
+	}
+	other.Release()
+	if got := vm.retired.LenInt64(); got != 0 {
+		t.Fatalf("expected no retired keys after every release, got %d", got)
+	}
+	if head, _ := vm.m.Get(1); chainLen(head) != 1 {
+		t.Fatalf("expected chain to shrink to 1 version, got %d", chainLen(head))
+	}
+}
+
+func TestSnapshotConsistentUnderConcurrentWrites(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	vm := NewVersioned[int, int](less)
+
+	const keys = 64
+	for k := range keys {
+		vm.Put(k, 0)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Each round rewrites keys in ascending order, so any point-in-time
+		// view holds a non-increasing sequence of rounds.
+		for round := 1; ; round++ {
+			for k := range keys {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				vm.Put(k, round)
+			}
+		}
+	}()
+
+	for range 200 {
+		snap := vm.Snapshot()
+		var rounds []int
+		for _, v := range snap.All() {
+			rounds = append(rounds, v)
+		}
+		if len(rounds) != keys {
+			t.Fatalf("expected %d keys in snapshot, got %d", keys, len(rounds))
+		}
+		for i := 1; i < len(rounds); i++ {
+			if rounds[i] > rounds[i-1] || rounds[0]-rounds[i] > 1 {
+				t.Fatalf("snapshot is not point-in-time: %v", rounds)
+			}
+		}
+		for k := range keys {
+			if got, _ := snap.Get(k); got != rounds[k] {
+				t.Fatalf("snapshot Get(%d)=%d disagrees with iteration %d", k, got, rounds[k])
+			}
+		}
+		snap.Release()
+	}
+
+	close(stop)
+	wg.Wait()
+}
+
+func chainLen[V any](head *version[V]) int {
+	n := 0
+	for ver := head; ver != nil; ver = ver.prev.Load() {
+		n++
+	}
+	return n
+}